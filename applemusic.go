@@ -13,6 +13,8 @@ import (
 	"strings"
 
 	"github.com/google/go-querystring/query"
+
+	"github.com/minchao/go-apple-music/token"
 )
 
 const (
@@ -28,6 +30,17 @@ type Client struct {
 	BaseURL   *url.URL
 	UserAgent string
 
+	// RetryPolicy, when set, retries the requests that failed with a Too Many Requests (429) or a server (5xx) error.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter, when set, limits the rate of the requests sent by all the services.
+	RateLimiter *RateLimiter
+
+	// BatchConcurrency is the maximum number of concurrent requests of the methods fetching resources
+	// by identifiers or ISRCs, which split the identifiers exceeding the per-request limit of the API into batches.
+	// If zero, 4 concurrent requests are used.
+	BatchConcurrency int
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the Apple Music API.
@@ -47,7 +60,36 @@ type Options struct {
 	Language string `url:"l,omitempty"`
 
 	// Additional relationships to include in the fetch.
-	Include string `url:"include,omitempty"`
+	Include []Relationship `url:"include,comma,omitempty"`
+
+	// Additional extended attributes to include in the fetch, such as artistBio or editorialVideo.
+	Extend []string `url:"extend,comma,omitempty"`
+
+	// The attributes and relationships to fetch of each resource type, such as the name of the albums.
+	// Only the listed fields of the resources of these types are returned.
+	Fields ResourceTypeValues `url:"fields,omitempty"`
+
+	// Additional relationships to include in the fetch of each resource type.
+	IncludeByType ResourceTypeValues `url:"include,omitempty"`
+
+	// Additional extended attributes to include in the fetch of each resource type.
+	ExtendByType ResourceTypeValues `url:"extend,omitempty"`
+}
+
+// ResourceTypeValues maps resource types to the values of a parameter scoped by resource type,
+// such as {"albums": {"name", "artistName"}} for the fields[albums]=name,artistName parameter.
+type ResourceTypeValues map[ResourceType][]string
+
+// EncodeValues implements the query.Encoder interface.
+// It encodes the values of each resource type as a key[type] parameter with comma-separated values.
+func (v ResourceTypeValues) EncodeValues(key string, values *url.Values) error {
+	for typ, vs := range v {
+		if len(vs) == 0 {
+			continue
+		}
+		values.Set(fmt.Sprintf("%s[%s]", key, typ), strings.Join(vs, ","))
+	}
+	return nil
 }
 
 // PageOptions specifies the optional parameters to support pagination of the objects.
@@ -93,6 +135,38 @@ func makeIsrcsOptions(isrcs []string, opt *Options) IsrcOptions {
 	return isrcsOpt
 }
 
+type UpcOptions struct {
+	Upcs string `url:"filter[upc]"`
+
+	Options
+}
+
+func makeUpcsOptions(upcs []string, opt *Options) UpcOptions {
+	upcsOpt := UpcOptions{
+		Upcs: strings.Join(upcs, ","),
+	}
+	if opt != nil {
+		upcsOpt.Options = *opt
+	}
+	return upcsOpt
+}
+
+type EquivalentsOptions struct {
+	Equivalents string `url:"filter[equivalents]"`
+
+	Options
+}
+
+func makeEquivalentsOptions(ids []string, opt *Options) EquivalentsOptions {
+	equivalentsOpt := EquivalentsOptions{
+		Equivalents: strings.Join(ids, ","),
+	}
+	if opt != nil {
+		equivalentsOpt.Options = *opt
+	}
+	return equivalentsOpt
+}
+
 // addOptions adds the parameters in opt as URL query parameters to s.
 // opt must be a struct whose fields may contain "url" tags.
 func addOptions(s string, opt interface{}) (string, error) {
@@ -192,9 +266,12 @@ func newResponse(r *http.Response) *Response {
 //
 // The provided ctx must be non-nil. If it is canceled or time out, ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if req.URL.Host != c.BaseURL.Host {
+		ctx = context.WithValue(ctx, foreignHostKey{}, true)
+	}
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -227,6 +304,15 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return response, err
 }
 
+// foreignHostKey is the context key marking the requests to hosts other than the BaseURL of the client,
+// such as the artwork images of the CDN, which are sent without the credentials, rate limiting and retries of the API.
+type foreignHostKey struct{}
+
+func isForeignHost(req *http.Request) bool {
+	foreign, _ := req.Context().Value(foreignHostKey{}).(bool)
+	return foreign
+}
+
 // Source represents the source of an error.
 type Source struct {
 	Parameter string      `json:"parameter"`
@@ -249,9 +335,20 @@ type Error struct {
 type ErrorResponse struct {
 	Response *http.Response
 	Errors   []Error `json:"errors"`
+
+	// Message holds the response body when it is not a JSON error document,
+	// such as the plain text or HTML body of a Not Found or a server error.
+	Message string `json:"-"`
 }
 
 func (r *ErrorResponse) Error() string {
+	if len(r.Errors) == 0 && r.Message != "" {
+		return fmt.Sprintf("%v %v: %d %s",
+			r.Response.Request.Method,
+			r.Response.Request.URL,
+			r.Response.StatusCode,
+			r.Message)
+	}
 	return fmt.Sprintf("%v %v: %d %+v",
 		r.Response.Request.Method,
 		r.Response.Request.URL,
@@ -302,14 +399,19 @@ func CheckResponse(r *http.Response) error {
 		}
 	case http.StatusTooManyRequests:
 		errorMessageResponse := &TooManyRequestsError{Response: r}
-		if err == nil && data != nil {
-			_ = json.Unmarshal(data, errorMessageResponse)
+		if err == nil && len(data) > 0 {
+			if json.Unmarshal(data, errorMessageResponse) != nil {
+				errorMessageResponse.Message = string(data)
+			}
 		}
 		return errorMessageResponse
 	default:
 		errorResponse := &ErrorResponse{Response: r}
-		if err == nil && data != nil {
-			_ = json.Unmarshal(data, errorResponse)
+		if err == nil && len(data) > 0 {
+			if json.Unmarshal(data, errorResponse) != nil || len(errorResponse.Errors) == 0 {
+				errorResponse.Errors = nil
+				errorResponse.Message = strings.TrimSpace(string(data))
+			}
 		}
 		return errorResponse
 	}
@@ -320,15 +422,36 @@ type Transport struct {
 	Token          string // Apple Music developer token
 	MusicUserToken string
 
+	// TokenSource supplies the developer token for each request, such as a token.Source
+	// that re-signs the token before it expires. If set, it takes precedence over Token.
+	TokenSource token.Provider
+
 	// Transport is the underlying HTTP transport to use when making requests.
 	// It will default to http.DefaultTransport if nil.
 	Transport http.RoundTripper
 }
 
 // RoundTrip implements the RoundTripper interface.
+// The requests sent by Client.Do to hosts other than the BaseURL of the client carry no tokens.
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isForeignHost(req) {
+		return t.transport().RoundTrip(req)
+	}
+
+	tok := t.Token
+	if t.TokenSource != nil {
+		var err error
+		tok, err = t.TokenSource.Token()
+		if err != nil {
+			if req.Body != nil {
+				req.Body.Close() // per RoundTrip contract
+			}
+			return nil, err
+		}
+	}
+
 	req = cloneRequest(req) // per RoundTrip contract
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.Token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tok))
 	if t.MusicUserToken != "" {
 		req.Header.Set("Music-User-Token", t.MusicUserToken)
 	}
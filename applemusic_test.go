@@ -136,6 +136,27 @@ func Test_addOptions_noParamsOverride(t *testing.T) {
 	}
 }
 
+func Test_addOptions_resourceTypeValues(t *testing.T) {
+	opt := &PageOptions{
+		Limit: 10,
+		Options: Options{
+			Extend:        []string{"artistBio"},
+			Fields:        ResourceTypeValues{"albums": {"name", "artistName"}, "songs": {"name"}},
+			IncludeByType: ResourceTypeValues{"albums": {"tracks"}},
+			ExtendByType:  ResourceTypeValues{"songs": {"artistUrl"}, "artists": nil},
+		},
+	}
+
+	want := "v1/catalog/us/albums/1?extend=artistBio&extend%5Bsongs%5D=artistUrl&fields%5Balbums%5D=name%2CartistName&fields%5Bsongs%5D=name&include%5Balbums%5D=tracks&limit=10"
+	got, err := addOptions("v1/catalog/us/albums/1", opt)
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("Url is %s, want %s", got, want)
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	c := NewClient(nil)
 
@@ -410,6 +431,54 @@ func TestTransport(t *testing.T) {
 	_, _ = c.Do(context.Background(), req, nil)
 }
 
+type tokenSourceFunc func() (string, error)
+
+func (f tokenSourceFunc) Token() (string, error) {
+	return f()
+}
+
+func TestTransport_tokenSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), fmt.Sprintf("Bearer TOKEN%d", calls); got != want {
+			t.Errorf("request contained token %s, want %s", got, want)
+		}
+	})
+
+	tp := &Transport{
+		Token: "STATIC_TOKEN",
+		TokenSource: tokenSourceFunc(func() (string, error) {
+			calls++
+			return fmt.Sprintf("TOKEN%d", calls), nil
+		}),
+	}
+	c := NewClient(tp.Client())
+	c.BaseURL = client.BaseURL
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest("GET", "/", nil)
+		_, _ = c.Do(context.Background(), req, nil)
+	}
+	if calls != 2 {
+		t.Errorf("TokenSource called %d times, want 2", calls)
+	}
+}
+
+func TestTransport_tokenSourceError(t *testing.T) {
+	tp := &Transport{
+		TokenSource: tokenSourceFunc(func() (string, error) {
+			return "", fmt.Errorf("token error")
+		}),
+	}
+	c := NewClient(tp.Client())
+	req, _ := c.NewRequest("GET", "/", nil)
+	if _, err := c.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
 func TestTransport_transport(t *testing.T) {
 	// default transport
 	tp := &Transport{}
@@ -0,0 +1,76 @@
+package applemusictest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Fault is an error response that the server returns instead of handling the matching requests,
+// such as Unauthorized (401), Too Many Requests (429) or a server (5xx) error.
+type Fault struct {
+	// Path is the prefix of the paths of the matching requests, such as /v1/catalog. All the requests match if empty.
+	Path string
+
+	// StatusCode is the status code of the error response. If zero, Internal Server Error (500) is used.
+	StatusCode int
+
+	// Count is the number of the requests to fail. If zero, all the matching requests fail until ClearFaults.
+	Count int
+
+	// RetryAfter, if positive, is the number of seconds sent in the Retry-After header of the error response.
+	RetryAfter int
+}
+
+// InjectFault makes the server fail the requests matching f.
+// The faults are matched in the order they are injected.
+func (s *Server) InjectFault(f Fault) {
+	if f.StatusCode == 0 {
+		f.StatusCode = http.StatusInternalServerError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all the injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// fault returns the first fault matching r, consuming one of its requests, or nil.
+func (s *Server) fault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+	}
+	if f.StatusCode == http.StatusUnauthorized {
+		// The API responds to unauthorized requests without body.
+		w.WriteHeader(f.StatusCode)
+		return
+	}
+	writeError(w, f.StatusCode, http.StatusText(f.StatusCode), "Injected fault")
+}
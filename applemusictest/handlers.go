@@ -0,0 +1,507 @@
+package applemusictest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minchao/go-apple-music"
+)
+
+// The default and maximum values of the limit parameters of the endpoints.
+const (
+	defaultLimit        = 25
+	maxLimit            = 100
+	defaultSearchLimit  = 5
+	maxSearchLimit      = 25
+	defaultChartLimit   = 20
+	maxChartLimit       = 50
+	defaultChartName    = "most-played"
+	storefrontsType     = "storefronts"
+	libraryPlaylistType = "library-playlists"
+)
+
+// The catalog resource types that are searched by default, and the names of the charts of the resource types.
+var (
+	searchTypes = []string{"songs", "albums", "artists", "playlists"}
+	chartNames  = map[string]string{"songs": "Top Songs", "albums": "Top Albums", "playlists": "Top Playlists"}
+)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if f := s.fault(r); f != nil {
+		writeFault(w, f)
+		return
+	}
+
+	s.mu.Lock()
+	developerToken, musicUserToken := s.developerToken, s.musicUserToken
+	s.mu.Unlock()
+
+	if developerToken != "" && r.Header.Get("Authorization") != "Bearer "+developerToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+		return
+	}
+
+	switch parts[1] {
+	case "storefronts":
+		s.serveStorefronts(w, r, parts[2:])
+	case "catalog":
+		s.serveCatalog(w, r, parts[2:])
+	case "me":
+		if musicUserToken != "" && r.Header.Get("Music-User-Token") != musicUserToken {
+			writeError(w, http.StatusForbidden, "Forbidden", "Invalid or missing music user token")
+			return
+		}
+		s.serveMe(w, r, parts[2:])
+	default:
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+	}
+}
+
+// serveStorefronts serves /v1/storefronts and /v1/storefronts/{id}.
+func (s *Server) serveStorefronts(w http.ResponseWriter, r *http.Request, parts []string) {
+	if !allowMethods(w, r, "GET") {
+		return
+	}
+
+	s.mu.Lock()
+	var entries []*entry
+	for _, sf := range s.storefronts {
+		entries = append(entries, &entry{id: sf.Id, value: sf})
+	}
+	s.mu.Unlock()
+
+	switch len(parts) {
+	case 0:
+		writeEntries(w, r, entries, defaultLimit, maxLimit)
+	case 1:
+		writeEntry(w, entries, parts[0], storefrontsType)
+	default:
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+	}
+}
+
+// serveCatalog serves /v1/catalog/{storefront}/...
+func (s *Server) serveCatalog(w http.ResponseWriter, r *http.Request, parts []string) {
+	if !allowMethods(w, r, "GET") {
+		return
+	}
+	if len(parts) < 2 {
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+		return
+	}
+	storefront, typ := parts[0], parts[1]
+
+	if !s.hasStorefront(storefront) {
+		writeError(w, http.StatusNotFound, "Resource Not Found", fmt.Sprintf("No storefront %s", storefront))
+		return
+	}
+
+	switch {
+	case typ == "search" && len(parts) == 2:
+		s.serveSearch(w, r, storefront)
+	case typ == "charts" && len(parts) == 2:
+		s.serveCharts(w, r, storefront)
+	case len(parts) == 2:
+		entries := s.catalogEntries(storefront, typ)
+		query := r.URL.Query()
+		switch {
+		case query.Get("filter[isrc]") != "":
+			writeJSON(w, http.StatusOK, data(filterIsrcs(entries, split(query.Get("filter[isrc]")))))
+		case query.Get("filter[upc]") != "":
+			writeJSON(w, http.StatusOK, data(filterUpcs(entries, split(query.Get("filter[upc]")))))
+		case query.Get("filter[equivalents]") != "":
+			writeJSON(w, http.StatusOK, data(s.equivalents(entries, typ, split(query.Get("filter[equivalents]")))))
+		default:
+			writeEntries(w, r, entries, defaultLimit, maxLimit)
+		}
+	case len(parts) == 3:
+		writeEntry(w, s.catalogEntries(storefront, typ), parts[2], typ)
+	default:
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+	}
+}
+
+// serveSearch serves /v1/catalog/{storefront}/search.
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request, storefront string) {
+	query := r.URL.Query()
+	words := strings.Fields(strings.ToLower(query.Get("term")))
+	if len(words) == 0 {
+		writeError(w, http.StatusBadRequest, "Parameter Error", "Missing term")
+		return
+	}
+	types := searchTypes
+	if t := query.Get("types"); t != "" {
+		types = split(t)
+	}
+
+	results := map[string]interface{}{}
+	for _, typ := range types {
+		var matches []*entry
+		for _, e := range s.catalogEntries(storefront, typ) {
+			if matchWords(e.terms, words) {
+				matches = append(matches, e)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		// The next pages of search results are searched by type.
+		typeQuery := cloneQuery(query)
+		typeQuery.Set("types", typ)
+		p, err := newPage(r.URL.Path, typeQuery, len(matches), defaultSearchLimit, maxSearchLimit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Parameter Error", err.Error())
+			return
+		}
+		results[typ] = p.collection(matches)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+// serveCharts serves /v1/catalog/{storefront}/charts, the charts of the resources in the order they were added.
+func (s *Server) serveCharts(w http.ResponseWriter, r *http.Request, storefront string) {
+	query := r.URL.Query()
+	types := split(query.Get("types"))
+	if len(types) == 0 {
+		writeError(w, http.StatusBadRequest, "Parameter Error", "Missing types")
+		return
+	}
+	chart := query.Get("chart")
+	if chart == "" {
+		chart = defaultChartName
+	}
+
+	results := map[string]interface{}{}
+	for _, typ := range types {
+		name, ok := chartNames[typ]
+		if !ok {
+			continue
+		}
+
+		entries := s.catalogEntries(storefront, typ)
+		p, err := newPage(r.URL.Path, query, len(entries), defaultChartLimit, maxChartLimit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Parameter Error", err.Error())
+			return
+		}
+		c := p.collection(entries)
+		c["chart"] = chart
+		c["name"] = name
+		results[typ] = []interface{}{c}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+// serveMe serves /v1/me/...
+func (s *Server) serveMe(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "storefront":
+		if !allowMethods(w, r, "GET") {
+			return
+		}
+		s.mu.Lock()
+		id := s.userStorefront
+		s.mu.Unlock()
+		s.serveStorefronts(w, r, []string{id})
+	case len(parts) >= 2 && parts[0] == "library":
+		s.serveLibrary(w, r, "library-"+parts[1], parts[2:])
+	default:
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+	}
+}
+
+// serveLibrary serves /v1/me/library/{type}/...
+func (s *Server) serveLibrary(w http.ResponseWriter, r *http.Request, typ string, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "POST" && typ == libraryPlaylistType:
+		s.createLibraryPlaylist(w, r)
+	case len(parts) == 0:
+		if allowMethods(w, r, "GET") {
+			writeEntries(w, r, s.libraryEntries(typ), defaultLimit, maxLimit)
+		}
+	case len(parts) == 1:
+		if allowMethods(w, r, "GET") {
+			writeEntry(w, s.libraryEntries(typ), parts[0], typ)
+		}
+	case len(parts) == 2 && parts[1] == "tracks" && typ == libraryPlaylistType:
+		s.serveLibraryPlaylistTracks(w, r, parts[0])
+	default:
+		writeError(w, http.StatusNotFound, "Resource Not Found", "No such endpoint")
+	}
+}
+
+func (s *Server) createLibraryPlaylist(w http.ResponseWriter, r *http.Request) {
+	var body applemusic.CreateLibraryPlaylist
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.nextPlaylistId++
+	playlist := applemusic.LibraryPlaylist{
+		Id: fmt.Sprintf("p.%d", s.nextPlaylistId),
+		Attributes: applemusic.LibraryPlaylistAttributes{
+			Name:      body.Attributes.Name,
+			CanDelete: true,
+			CanEdit:   true,
+		},
+	}
+	if body.Attributes.Description != "" {
+		playlist.Attributes.Description = &applemusic.EditorialNotes{Standard: body.Attributes.Description}
+	}
+	s.addLibraryPlaylist(playlist)
+	if body.Relationships != nil {
+		s.playlistTracks[playlist.Id] = append(s.playlistTracks[playlist.Id], body.Relationships.Tracks.Data...)
+	}
+	e := s.library[libraryPlaylistType][len(s.library[libraryPlaylistType])-1]
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, data([]*entry{e}))
+}
+
+// serveLibraryPlaylistTracks serves /v1/me/library/playlists/{id}/tracks.
+// The tracks are the library songs, or the catalog songs of the storefront of the user, added to the playlist.
+func (s *Server) serveLibraryPlaylistTracks(w http.ResponseWriter, r *http.Request, id string) {
+	if findEntry(s.libraryEntries(libraryPlaylistType), id) == nil {
+		writeError(w, http.StatusNotFound, "Resource Not Found", fmt.Sprintf("No library-playlists %s", id))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		s.mu.Lock()
+		tracks := s.playlistTracks[id]
+		storefront := s.userStorefront
+		s.mu.Unlock()
+
+		var entries []*entry
+		for _, track := range tracks {
+			var candidates []*entry
+			if strings.HasPrefix(track.Type, "library-") {
+				candidates = s.libraryEntries(track.Type)
+			} else {
+				candidates = s.catalogEntries(storefront, track.Type)
+			}
+			if e := findEntry(candidates, track.Id); e != nil {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			writeError(w, http.StatusNotFound, "Resource Not Found", "No tracks")
+			return
+		}
+		writeEntries(w, r, entries, defaultLimit, maxLimit)
+	case "POST":
+		var body applemusic.CreateLibraryPlaylistTrackData
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid Request Body", err.Error())
+			return
+		}
+		s.mu.Lock()
+		s.playlistTracks[id] = append(s.playlistTracks[id], body.Data...)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		allowMethods(w, r, "GET", "POST")
+	}
+}
+
+func (s *Server) hasStorefront(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sf := range s.storefronts {
+		if sf.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) catalogEntries(storefront, typ string) []*entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.catalog[catalogKey(storefront, typ)]
+}
+
+func (s *Server) libraryEntries(typ string) []*entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.library[typ]
+}
+
+// writeEntries writes the entries identified by the ids parameter, or a page of all the entries.
+func writeEntries(w http.ResponseWriter, r *http.Request, entries []*entry, defaultLimit, maxLimit int) {
+	if ids := r.URL.Query().Get("ids"); ids != "" {
+		var found []*entry
+		for _, id := range split(ids) {
+			if e := findEntry(entries, id); e != nil {
+				found = append(found, e)
+			}
+		}
+		writeJSON(w, http.StatusOK, data(found))
+		return
+	}
+
+	p, err := newPage(r.URL.Path, r.URL.Query(), len(entries), defaultLimit, maxLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Parameter Error", err.Error())
+		return
+	}
+	c := p.collection(entries)
+	c["meta"] = map[string]int{"total": len(entries)}
+	writeJSON(w, http.StatusOK, c)
+}
+
+// writeEntry writes the entry identified by id, or a Not Found (404) error.
+func writeEntry(w http.ResponseWriter, entries []*entry, id, typ string) {
+	e := findEntry(entries, id)
+	if e == nil {
+		writeError(w, http.StatusNotFound, "Resource Not Found", fmt.Sprintf("No %s %s", typ, id))
+		return
+	}
+	writeJSON(w, http.StatusOK, data([]*entry{e}))
+}
+
+func findEntry(entries []*entry, id string) *entry {
+	for _, e := range entries {
+		if e.id == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func filterIsrcs(entries []*entry, isrcs []string) []*entry {
+	var found []*entry
+	for _, e := range entries {
+		if e.isrc != "" && contains(isrcs, e.isrc) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func filterUpcs(entries []*entry, upcs []string) []*entry {
+	var found []*entry
+	for _, e := range entries {
+		if e.upc != "" && contains(upcs, e.upc) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// equivalents returns the entries equivalent to the resources of ids in any storefront:
+// the entry with the same identifier, or else the entries with the same ISRC or UPC.
+func (s *Server) equivalents(entries []*entry, typ string, ids []string) []*entry {
+	var found []*entry
+	for _, id := range ids {
+		if e := findEntry(entries, id); e != nil {
+			found = append(found, e)
+			continue
+		}
+		source := s.findCatalogEntry(typ, id)
+		if source == nil {
+			continue
+		}
+		for _, e := range entries {
+			if source.isrc != "" && e.isrc == source.isrc || source.upc != "" && e.upc == source.upc {
+				found = append(found, e)
+			}
+		}
+	}
+	return found
+}
+
+// findCatalogEntry returns the entry of the type identified by id in any storefront.
+func (s *Server) findCatalogEntry(typ, id string) *entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, entries := range s.catalog {
+		if strings.HasSuffix(key, "/"+typ) {
+			if e := findEntry(entries, id); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func matchWords(terms string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(terms, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func data(entries []*entry) map[string]interface{} {
+	values := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		values = append(values, e.value)
+	}
+	return map[string]interface{}{"data": values}
+}
+
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	if contains(methods, r.Method) {
+		return true
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", fmt.Sprintf("Method %s is not allowed", r.Method))
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, title, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []applemusic.Error{
+			{
+				Id:     strconv.Itoa(status),
+				Title:  title,
+				Detail: detail,
+				Status: strconv.Itoa(status),
+				Code:   strconv.Itoa(status * 100),
+			},
+		},
+	})
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package applemusictest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// page is a page of a collection, selected by the limit and offset parameters.
+type page struct {
+	start, end int
+	href, next string
+}
+
+func newPage(path string, query url.Values, total, defaultLimit, maxLimit int) (*page, error) {
+	limit, err := intParam(query, "limit", defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, fmt.Errorf("value of limit must be between 1 and %d", maxLimit)
+	}
+	offset, err := intParam(query, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("value of offset must not be negative")
+	}
+
+	p := &page{start: offset, end: offset + limit, href: path + "?" + query.Encode()}
+	if p.start > total {
+		p.start = total
+	}
+	if p.end >= total {
+		p.end = total
+	} else {
+		next := cloneQuery(query)
+		next.Set("offset", strconv.Itoa(p.end))
+		p.next = path + "?" + next.Encode()
+	}
+	return p, nil
+}
+
+// collection returns the response of the page of entries, with the data, href and next members.
+func (p *page) collection(entries []*entry) map[string]interface{} {
+	c := data(entries[p.start:p.end])
+	c["href"] = p.href
+	if p.next != "" {
+		c["next"] = p.next
+	}
+	return c
+}
+
+func intParam(query url.Values, name string, defaultValue int) (int, error) {
+	s := query.Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("value of %s must be an integer", name)
+	}
+	return n, nil
+}
+
+func cloneQuery(query url.Values) url.Values {
+	clone := url.Values{}
+	for k, v := range query {
+		clone[k] = append([]string(nil), v...)
+	}
+	return clone
+}
@@ -0,0 +1,301 @@
+package applemusictest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+)
+
+// ErrNoInteraction is returned by a Recorder in replay mode when no recorded interaction matches the request.
+var ErrNoInteraction = errors.New("applemusictest: no recorded interaction matches the request")
+
+// scrubbedHeaders are the request headers carrying credentials, which are never written to the fixture file.
+var scrubbedHeaders = []string{"Authorization", "Music-User-Token"}
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves the requests from the fixture file, without network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends the requests to the underlying transport and writes the interactions to the fixture file.
+	ModeRecord
+)
+
+// Matching is how a Recorder in replay mode matches the requests with the recorded interactions.
+type Matching int
+
+const (
+	// MatchStrict matches the method, the URL, with the query parameters in any order, and the body of the request.
+	MatchStrict Matching = iota
+
+	// MatchLenient matches the method and the path of the request only.
+	// The interactions with the same query parameters are preferred.
+	MatchLenient
+)
+
+// Interaction is a request and its response, as recorded in a fixture file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request, without the Authorization and Music-User-Token headers.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records the interactions with the API to a fixture file,
+// and replays them offline, for deterministic integration tests.
+//
+// The fixture file is a JSON array of interactions, rewritten after each recorded request.
+// The Authorization and Music-User-Token headers are scrubbed from the recorded requests,
+// therefore Recorder must be placed under applemusic.Transport, which sets them:
+//
+//	rec, err := applemusictest.NewRecorder("testdata/search.json", applemusictest.ModeReplay)
+//	tp := applemusic.Transport{
+//		Token:     os.Getenv("APPLE_MUSIC_API_TOKEN"),
+//		Transport: rec,
+//	}
+type Recorder struct {
+	// Path is the path of the fixture file.
+	Path string
+
+	// Mode is the mode of the recorder.
+	Mode Mode
+
+	// Matching is how the requests are matched in replay mode, MatchStrict by default.
+	Matching Matching
+
+	// Transport is the underlying HTTP transport to use when recording.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a new Recorder of the fixture file at path.
+// In replay mode, the fixture file is loaded and must exist.
+// In record mode, the interactions are recorded from scratch, replacing the fixture file.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{Path: path, Mode: mode}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("applemusictest: invalid fixture file %s: %v", path, err)
+	}
+	r.replayed = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// Client returns an *http.Client that makes requests through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the recorded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.interactions))
+	for i, in := range r.interactions {
+		interactions[i] = *in
+	}
+	return interactions
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	if body != nil {
+		req = req.Clone(req.Context()) // per RoundTrip contract
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := req.Header.Clone()
+	for _, h := range scrubbedHeaders {
+		header.Del(h)
+	}
+	in := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: header,
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, in)
+	r.replayed = append(r.replayed, false)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the interactions to the fixture file atomically.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(r.Path), "tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), r.Path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.find(req, body)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+	}
+	r.replayed[i] = true
+
+	in := r.interactions[i]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+		StatusCode:    in.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Response.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(in.Response.Body))),
+		ContentLength: int64(len(in.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// find returns the index of the interaction to replay for the request, or -1.
+// The interactions matching the same request are replayed in the recorded order, the last one repeatedly.
+func (r *Recorder) find(req *http.Request, body []byte) int {
+	best, bestScore := -1, 0
+	for i, in := range r.interactions {
+		score := r.match(in, req, body) * 2
+		if score == 0 {
+			continue
+		}
+		if !r.replayed[i] {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		} else if score == bestScore && r.replayed[i] {
+			best = i
+		}
+	}
+	return best
+}
+
+// match returns 0 if the interaction does not match the request, 2 if it matches with the same query parameters,
+// and 1 if it matches leniently with different ones.
+func (r *Recorder) match(in *Interaction, req *http.Request, body []byte) int {
+	u, err := url.Parse(in.Request.URL)
+	if err != nil || in.Request.Method != req.Method || u.Path != req.URL.Path {
+		return 0
+	}
+	sameQuery := reflect.DeepEqual(u.Query(), req.URL.Query())
+
+	if r.Matching == MatchLenient {
+		if sameQuery {
+			return 2
+		}
+		return 1
+	}
+	if !sameQuery || u.Scheme != req.URL.Scheme || u.Host != req.URL.Host || in.Request.Body != string(body) {
+		return 0
+	}
+	return 2
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport != nil {
+		return r.Transport
+	}
+	return http.DefaultTransport
+}
+
+// readBody reads and closes the body of the request, if any.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return ioutil.ReadAll(req.Body)
+}
@@ -0,0 +1,106 @@
+package applemusictest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minchao/go-apple-music"
+)
+
+func newRecorderClient(baseURL string, rec *Recorder) *applemusic.Client {
+	tp := &applemusic.Transport{
+		Token:          "developer-token",
+		MusicUserToken: "music-user-token",
+		Transport:      rec,
+	}
+	client := applemusic.NewClient(tp.Client())
+	client.BaseURL, _ = url.Parse(baseURL + "/")
+	return client
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "applemusictest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixtures", "songs.json")
+
+	s := newTestServer()
+	s.SetTokens("developer-token", "music-user-token")
+	s.AddLibrarySongs(applemusic.LibrarySong{Id: "i.1"})
+	baseURL := s.URL
+	ctx := context.Background()
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client := newRecorderClient(baseURL, rec)
+	if _, _, err := client.Catalog.GetSongsByIds(ctx, "us", []string{"1", "2"}, nil); err != nil {
+		t.Fatalf("Catalog.GetSongsByIds returned error: %v", err)
+	}
+	if _, _, err := client.Me.GetAllLibrarySongs(ctx, nil); err != nil {
+		t.Fatalf("Me.GetAllLibrarySongs returned error: %v", err)
+	}
+	s.Close()
+
+	if n := len(rec.Interactions()); n != 2 {
+		t.Errorf("Recorder recorded %d interactions, want 2", n)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	for _, secret := range []string{"developer-token", "music-user-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Fixture file contains %q", secret)
+		}
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client = newRecorderClient(baseURL, rec)
+	songs, _, err := client.Catalog.GetSongsByIds(ctx, "us", []string{"1", "2"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByIds returned error: %v", err)
+	}
+	if len(songs.Data) != 2 || songs.Data[0].Attributes.Name != "Sunday Bloody Sunday" {
+		t.Errorf("Catalog.GetSongsByIds = %+v, want songs 1 and 2", songs.Data)
+	}
+	library, _, err := client.Me.GetAllLibrarySongs(ctx, nil)
+	if err != nil {
+		t.Fatalf("Me.GetAllLibrarySongs returned error: %v", err)
+	}
+	if len(library.Data) != 1 || library.Data[0].Id != "i.1" {
+		t.Errorf("Me.GetAllLibrarySongs = %+v, want song i.1", library.Data)
+	}
+
+	_, _, err = client.Catalog.GetSongsByIds(ctx, "us", []string{"3"}, nil)
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Catalog.GetSongsByIds returned error %v, want ErrNoInteraction", err)
+	}
+
+	rec.Matching = MatchLenient
+	songs, _, err = client.Catalog.GetSongsByIds(ctx, "us", []string{"3"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByIds returned error: %v", err)
+	}
+	if len(songs.Data) != 2 {
+		t.Errorf("Catalog.GetSongsByIds = %+v, want the recorded songs 1 and 2", songs.Data)
+	}
+}
+
+func TestNewRecorder_replayMissingFixture(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(os.TempDir(), "applemusictest-missing.json"), ModeReplay); !os.IsNotExist(err) {
+		t.Errorf("NewRecorder returned error %v, want not exist", err)
+	}
+}
@@ -0,0 +1,277 @@
+// Package applemusictest provides a fake Apple Music API server for testing the users of the applemusic package.
+//
+// The server is backed by an in-memory catalog and library that the test seeds with typed resources:
+//
+//	srv := applemusictest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddStorefronts(applemusic.Storefront{Id: "us"})
+//	srv.AddSongs("us", applemusic.Song{Id: "1", Attributes: applemusic.SongAttributes{Name: "One"}})
+//
+//	client := srv.Client()
+//	songs, _, err := client.Catalog.GetSong(ctx, "us", "1", nil)
+package applemusictest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/minchao/go-apple-music"
+)
+
+// Server is a fake Apple Music API server.
+type Server struct {
+	// URL is the base URL of the server, such as http://127.0.0.1:1234.
+	URL string
+
+	server *httptest.Server
+
+	mu sync.Mutex
+
+	// The developer token and music user token that the requests must carry, if not empty.
+	developerToken string
+	musicUserToken string
+
+	userStorefront string
+	storefronts    []applemusic.Storefront
+
+	// The catalog resources, keyed by storefront and type, and the library resources, keyed by type.
+	catalog map[string][]*entry
+	library map[string][]*entry
+
+	// The tracks of the library playlists, keyed by the identifier of the playlist.
+	playlistTracks map[string][]applemusic.CreateLibraryPlaylistTrack
+	nextPlaylistId int
+
+	faults []*Fault
+}
+
+// entry is a seeded resource.
+type entry struct {
+	id    string
+	isrc  string
+	upc   string
+	terms string // The lower-case text matched by the search, such as the name of the resource.
+	value interface{}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		userStorefront: "us",
+		catalog:        map[string][]*entry{},
+		library:        map[string][]*entry{},
+		playlistTracks: map[string][]applemusic.CreateLibraryPlaylistTrack{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an applemusic.Client that sends its requests to the server,
+// with the tokens set by SetTokens.
+func (s *Server) Client() *applemusic.Client {
+	s.mu.Lock()
+	tp := &applemusic.Transport{
+		Token:          s.developerToken,
+		MusicUserToken: s.musicUserToken,
+	}
+	s.mu.Unlock()
+
+	client := applemusic.NewClient(tp.Client())
+	client.BaseURL, _ = url.Parse(s.URL + "/")
+	return client
+}
+
+// SetTokens sets the developer token that all the requests must carry, and the music user token
+// that the requests to the /v1/me endpoints must carry. An empty token is not checked.
+// The requests without the developer token fail with Unauthorized (401),
+// and the requests without the music user token fail with Forbidden (403).
+func (s *Server) SetTokens(developerToken, musicUserToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.developerToken = developerToken
+	s.musicUserToken = musicUserToken
+}
+
+// SetUserStorefront sets the identifier of the storefront of the user, us by default.
+func (s *Server) SetUserStorefront(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userStorefront = id
+}
+
+// AddStorefronts adds storefronts.
+func (s *Server) AddStorefronts(storefronts ...applemusic.Storefront) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sf := range storefronts {
+		if sf.Type == "" {
+			sf.Type = "storefronts"
+		}
+		if sf.Href == "" {
+			sf.Href = "/v1/storefronts/" + sf.Id
+		}
+		s.storefronts = append(s.storefronts, sf)
+	}
+}
+
+// AddSongs adds songs to the catalog of the storefront.
+// The songs are searched by their name and artist name, and fetched by their identifier or ISRC.
+func (s *Server) AddSongs(storefront string, songs ...applemusic.Song) {
+	for _, song := range songs {
+		song.Type, song.Href = defaults(song.Type, song.Href, storefront, "songs", song.Id)
+		s.addCatalog(storefront, "songs", &entry{
+			id:    song.Id,
+			isrc:  song.Attributes.ISRC,
+			terms: song.Attributes.Name + " " + song.Attributes.ArtistName,
+			value: song,
+		})
+	}
+}
+
+// AddAlbums adds albums to the catalog of the storefront.
+// The albums are searched by their name and artist name, and fetched by their identifier or UPC.
+func (s *Server) AddAlbums(storefront string, albums ...applemusic.Album) {
+	for _, album := range albums {
+		album.Type, album.Href = defaults(album.Type, album.Href, storefront, "albums", album.Id)
+		s.addCatalog(storefront, "albums", &entry{
+			id:    album.Id,
+			upc:   album.Attributes.UPC,
+			terms: album.Attributes.Name + " " + album.Attributes.ArtistName,
+			value: album,
+		})
+	}
+}
+
+// AddMusicVideos adds music videos to the catalog of the storefront.
+// The music videos are searched by their name and artist name, and fetched by their identifier or ISRC.
+func (s *Server) AddMusicVideos(storefront string, musicVideos ...applemusic.MusicVideo) {
+	for _, musicVideo := range musicVideos {
+		musicVideo.Type, musicVideo.Href = defaults(musicVideo.Type, musicVideo.Href, storefront, "music-videos", musicVideo.Id)
+		s.addCatalog(storefront, "music-videos", &entry{
+			id:    musicVideo.Id,
+			isrc:  musicVideo.Attributes.ISRC,
+			terms: musicVideo.Attributes.Name + " " + musicVideo.Attributes.ArtistName,
+			value: musicVideo,
+		})
+	}
+}
+
+// AddArtists adds artists to the catalog of the storefront.
+// The artists are searched by their name.
+func (s *Server) AddArtists(storefront string, artists ...applemusic.Artist) {
+	for _, artist := range artists {
+		artist.Type, artist.Href = defaults(artist.Type, artist.Href, storefront, "artists", artist.Id)
+		s.addCatalog(storefront, "artists", &entry{
+			id:    artist.Id,
+			terms: artist.Attributes.Name,
+			value: artist,
+		})
+	}
+}
+
+// AddPlaylists adds playlists to the catalog of the storefront.
+// The playlists are searched by their name and curator name.
+func (s *Server) AddPlaylists(storefront string, playlists ...applemusic.Playlist) {
+	for _, playlist := range playlists {
+		playlist.Type, playlist.Href = defaults(playlist.Type, playlist.Href, storefront, "playlists", playlist.Id)
+		s.addCatalog(storefront, "playlists", &entry{
+			id:    playlist.Id,
+			terms: playlist.Attributes.Name + " " + playlist.Attributes.CuratorName,
+			value: playlist,
+		})
+	}
+}
+
+// AddLibrarySongs adds songs to the library of the user.
+func (s *Server) AddLibrarySongs(songs ...applemusic.LibrarySong) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, song := range songs {
+		if song.Type == "" {
+			song.Type = "library-songs"
+		}
+		if song.Href == "" {
+			song.Href = "/v1/me/library/songs/" + song.Id
+		}
+		s.library["library-songs"] = append(s.library["library-songs"], &entry{id: song.Id, value: song})
+	}
+}
+
+// AddLibraryPlaylists adds playlists to the library of the user.
+func (s *Server) AddLibraryPlaylists(playlists ...applemusic.LibraryPlaylist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, playlist := range playlists {
+		s.addLibraryPlaylist(playlist)
+	}
+}
+
+func (s *Server) addLibraryPlaylist(playlist applemusic.LibraryPlaylist) {
+	if playlist.Type == "" {
+		playlist.Type = "library-playlists"
+	}
+	if playlist.Href == "" {
+		playlist.Href = "/v1/me/library/playlists/" + playlist.Id
+	}
+	s.library["library-playlists"] = append(s.library["library-playlists"], &entry{id: playlist.Id, value: playlist})
+}
+
+// LibraryPlaylists returns the playlists in the library of the user, including the created ones.
+func (s *Server) LibraryPlaylists() []applemusic.LibraryPlaylist {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var playlists []applemusic.LibraryPlaylist
+	for _, e := range s.library["library-playlists"] {
+		playlists = append(playlists, e.value.(applemusic.LibraryPlaylist))
+	}
+	return playlists
+}
+
+// LibraryPlaylistTracks returns the tracks added to the library playlist,
+// when it was created or with MeService.AddLibraryTracksToPlaylist.
+func (s *Server) LibraryPlaylistTracks(id string) []applemusic.CreateLibraryPlaylistTrack {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]applemusic.CreateLibraryPlaylistTrack(nil), s.playlistTracks[id]...)
+}
+
+func (s *Server) addCatalog(storefront, typ string, e *entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.terms = strings.ToLower(e.terms)
+	key := catalogKey(storefront, typ)
+	s.catalog[key] = append(s.catalog[key], e)
+}
+
+func catalogKey(storefront, typ string) string {
+	return storefront + "/" + typ
+}
+
+func defaults(typ, href, storefront, defaultType, id string) (string, string) {
+	if typ == "" {
+		typ = defaultType
+	}
+	if href == "" {
+		href = fmt.Sprintf("/v1/catalog/%s/%s/%s", storefront, defaultType, id)
+	}
+	return typ, href
+}
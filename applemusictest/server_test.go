@@ -0,0 +1,358 @@
+package applemusictest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minchao/go-apple-music"
+)
+
+func newTestServer() *Server {
+	s := NewServer()
+	s.AddStorefronts(
+		applemusic.Storefront{Id: "us", Attributes: applemusic.StorefrontAttributes{Name: "United States"}},
+		applemusic.Storefront{Id: "jp", Attributes: applemusic.StorefrontAttributes{Name: "Japan"}},
+	)
+	s.AddSongs("us",
+		applemusic.Song{Id: "1", Attributes: applemusic.SongAttributes{Name: "Sunday Bloody Sunday", ArtistName: "U2", ISRC: "GBUM71029604"}},
+		applemusic.Song{Id: "2", Attributes: applemusic.SongAttributes{Name: "New Year's Day", ArtistName: "U2", ISRC: "GBUM71029605"}},
+		applemusic.Song{Id: "3", Attributes: applemusic.SongAttributes{Name: "Blue Monday", ArtistName: "New Order"}},
+	)
+	s.AddAlbums("us", applemusic.Album{Id: "10", Attributes: applemusic.AlbumAttributes{Name: "War", ArtistName: "U2"}})
+	s.AddArtists("us", applemusic.Artist{Id: "20", Attributes: applemusic.ArtistAttributes{Name: "U2"}})
+	s.AddPlaylists("us", applemusic.Playlist{Id: "pl.1", Attributes: applemusic.PlaylistAttributes{Name: "U2 Essentials"}})
+	return s
+}
+
+func TestServer_storefronts(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+	s.SetUserStorefront("jp")
+
+	client := s.Client()
+	ctx := context.Background()
+
+	all, _, err := client.Storefront.GetAll(ctx, nil)
+	if err != nil {
+		t.Fatalf("Storefront.GetAll returned error: %v", err)
+	}
+	if len(all.Data) != 2 || all.Data[0].Id != "us" || all.Data[0].Type != "storefronts" {
+		t.Errorf("Storefront.GetAll = %+v, want us and jp", all)
+	}
+
+	me, _, err := client.Me.GetStorefront(ctx, nil)
+	if err != nil {
+		t.Fatalf("Me.GetStorefront returned error: %v", err)
+	}
+	if len(me.Data) != 1 || me.Data[0].Attributes.Name != "Japan" {
+		t.Errorf("Me.GetStorefront = %+v, want Japan", me)
+	}
+
+	if _, _, err := client.Storefront.Get(ctx, "xx", nil); !errors.Is(err, applemusic.ErrNotFound) {
+		t.Errorf("Storefront.Get returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_catalog(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	client := s.Client()
+	ctx := context.Background()
+
+	songs, _, err := client.Catalog.GetSong(ctx, "us", "1", nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSong returned error: %v", err)
+	}
+	want := applemusic.Song{
+		Id:         "1",
+		Type:       "songs",
+		Href:       "/v1/catalog/us/songs/1",
+		Attributes: applemusic.SongAttributes{Name: "Sunday Bloody Sunday", ArtistName: "U2", ISRC: "GBUM71029604"},
+	}
+	if len(songs.Data) != 1 || !reflect.DeepEqual(songs.Data[0], want) {
+		t.Errorf("Catalog.GetSong = %+v, want %+v", songs.Data, want)
+	}
+
+	if _, _, err := client.Catalog.GetSong(ctx, "us", "404", nil); !errors.Is(err, applemusic.ErrNotFound) {
+		t.Errorf("Catalog.GetSong returned error %v, want ErrNotFound", err)
+	}
+	if _, _, err := client.Catalog.GetSong(ctx, "jp", "1", nil); !errors.Is(err, applemusic.ErrNotFound) {
+		t.Errorf("Catalog.GetSong returned error %v, want ErrNotFound", err)
+	}
+
+	ids := []string{"3", "404", "1"}
+	songs, _, err = client.Catalog.GetSongsByIds(ctx, "us", ids, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByIds returned error: %v", err)
+	}
+	if got, want := applemusic.MissingIds(ids, songs), []string{"404"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingIds = %v, want %v", got, want)
+	}
+
+	isrcs := []string{"GBUM71029605", "XXXX00000000"}
+	songs, _, err = client.Catalog.GetSongsByIsrcs(ctx, "us", isrcs, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByIsrcs returned error: %v", err)
+	}
+	if len(songs.Data) != 1 || songs.Data[0].Id != "2" {
+		t.Errorf("Catalog.GetSongsByIsrcs = %+v, want song 2", songs.Data)
+	}
+}
+
+func TestServer_filters(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	s.AddSongs("jp", applemusic.Song{Id: "101", Attributes: applemusic.SongAttributes{Name: "Sunday Bloody Sunday", ISRC: "GBUM71029604"}})
+	s.AddAlbums("us", applemusic.Album{Id: "11", Attributes: applemusic.AlbumAttributes{Name: "Boy", UPC: "602517648441"}})
+	s.AddAlbums("jp", applemusic.Album{Id: "111", Attributes: applemusic.AlbumAttributes{Name: "Boy", UPC: "602517648441"}})
+	s.AddMusicVideos("us", applemusic.MusicVideo{Id: "30", Attributes: applemusic.MusicVideoAttributes{Name: "One", ISRC: "GBUM79100001"}})
+
+	client := s.Client()
+	ctx := context.Background()
+
+	albums, _, err := client.Catalog.GetAlbumsByUpcs(ctx, "us", []string{"602517648441"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetAlbumsByUpcs returned error: %v", err)
+	}
+	if len(albums.Data) != 1 || albums.Data[0].Id != "11" {
+		t.Errorf("Catalog.GetAlbumsByUpcs = %+v, want album 11", albums.Data)
+	}
+
+	musicVideos, _, err := client.Catalog.GetMusicVideosByIsrcs(ctx, "us", []string{"GBUM79100001"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetMusicVideosByIsrcs returned error: %v", err)
+	}
+	if len(musicVideos.Data) != 1 || musicVideos.Data[0].Id != "30" {
+		t.Errorf("Catalog.GetMusicVideosByIsrcs = %+v, want music video 30", musicVideos.Data)
+	}
+
+	songs, _, err := client.Catalog.GetSongsByEquivalents(ctx, "jp", []string{"1", "2"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByEquivalents returned error: %v", err)
+	}
+	if len(songs.Data) != 1 || songs.Data[0].Id != "101" {
+		t.Errorf("Catalog.GetSongsByEquivalents = %+v, want song 101", songs.Data)
+	}
+
+	albums, _, err = client.Catalog.GetAlbumsByEquivalents(ctx, "jp", []string{"11"}, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetAlbumsByEquivalents returned error: %v", err)
+	}
+	if len(albums.Data) != 1 || albums.Data[0].Id != "111" {
+		t.Errorf("Catalog.GetAlbumsByEquivalents = %+v, want album 111", albums.Data)
+	}
+}
+
+func TestServer_search(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	client := s.Client()
+	ctx := context.Background()
+
+	search, _, err := client.Catalog.Search(ctx, "us", &applemusic.SearchOptions{Term: "u2"})
+	if err != nil {
+		t.Fatalf("Catalog.Search returned error: %v", err)
+	}
+	results := search.Results
+	if results.Songs == nil || len(results.Songs.Data) != 2 {
+		t.Errorf("Catalog.Search songs = %+v, want 2 songs", results.Songs)
+	}
+	if results.Albums == nil || results.Artists == nil || results.Playlists == nil {
+		t.Errorf("Catalog.Search = %+v, want albums, artists and playlists", results)
+	}
+
+	opt := &applemusic.SearchOptions{
+		Term:  "sunday u2",
+		Types: []applemusic.ResourceType{applemusic.ResourceTypeSongs},
+		Limit: 1,
+	}
+	search, _, err = client.Catalog.Search(ctx, "us", opt)
+	if err != nil {
+		t.Fatalf("Catalog.Search returned error: %v", err)
+	}
+	if songs := search.Results.Songs; songs == nil || len(songs.Data) != 1 || songs.Data[0].Id != "1" || songs.Next != "" {
+		t.Errorf("Catalog.Search songs = %+v, want song 1 only", songs)
+	}
+	if search.Results.Albums != nil {
+		t.Errorf("Catalog.Search albums = %+v, want none", search.Results.Albums)
+	}
+}
+
+func TestServer_paging(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	client := s.Client()
+	ctx := context.Background()
+
+	charts, _, err := client.Catalog.GetAllCharts(ctx, "us", &applemusic.ChartsOptions{
+		Types: []applemusic.ResourceType{applemusic.ResourceTypeSongs},
+		Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("Catalog.GetAllCharts returned error: %v", err)
+	}
+	if charts.Results.Songs == nil || len(*charts.Results.Songs) != 1 {
+		t.Fatalf("Catalog.GetAllCharts = %+v, want a chart of songs", charts.Results)
+	}
+	chart := (*charts.Results.Songs)[0]
+	if chart.Chart != "most-played" || len(chart.Data) != 2 || chart.Next == "" {
+		t.Errorf("Catalog.GetAllCharts songs = %+v, want the first 2 of the most played songs", chart)
+	}
+
+	s.AddLibrarySongs(
+		applemusic.LibrarySong{Id: "i.1"},
+		applemusic.LibrarySong{Id: "i.2"},
+		applemusic.LibrarySong{Id: "i.3"},
+	)
+	songs, _, err := client.Me.GetAllLibrarySongs(ctx, &applemusic.PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Me.GetAllLibrarySongs returned error: %v", err)
+	}
+	if len(songs.Data) != 2 || songs.Next == "" {
+		t.Errorf("Me.GetAllLibrarySongs = %+v, want the first 2 songs", songs)
+	}
+	if _, err := client.CollectAll(ctx, songs, nil); err != nil {
+		t.Fatalf("CollectAll returned error: %v", err)
+	}
+	var ids []string
+	for _, song := range songs.Data {
+		ids = append(ids, song.Id)
+	}
+	if want := []string{"i.1", "i.2", "i.3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("CollectAll = %v, want %v", ids, want)
+	}
+
+	if _, _, err := client.Me.GetAllLibrarySongs(ctx, &applemusic.PageOptions{Limit: 101}); !errors.Is(err, applemusic.ErrBadParameter) {
+		t.Errorf("Me.GetAllLibrarySongs returned error %v, want ErrBadParameter", err)
+	}
+}
+
+func TestServer_libraryPlaylists(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+	s.AddLibrarySongs(applemusic.LibrarySong{Id: "i.1", Attributes: applemusic.LibrarySongAttributes{Name: "Gloria"}})
+
+	client := s.Client()
+	ctx := context.Background()
+
+	created, _, err := client.Me.CreateLibraryPlaylist(ctx, applemusic.CreateLibraryPlaylist{
+		Attributes: applemusic.CreateLibraryPlaylistAttributes{Name: "Road Trip", Description: "Songs for the road"},
+		Relationships: &applemusic.CreateLibraryPlaylistRelationships{
+			Tracks: applemusic.CreateLibraryPlaylistTrackData{
+				Data: []applemusic.CreateLibraryPlaylistTrack{{Id: "1", Type: "songs"}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Me.CreateLibraryPlaylist returned error: %v", err)
+	}
+	if len(created.Data) != 1 || created.Data[0].Attributes.Name != "Road Trip" {
+		t.Fatalf("Me.CreateLibraryPlaylist = %+v, want the Road Trip playlist", created)
+	}
+	id := created.Data[0].Id
+
+	_, err = client.Me.AddLibraryTracksToPlaylist(ctx, id, applemusic.CreateLibraryPlaylistTrackData{
+		Data: []applemusic.CreateLibraryPlaylistTrack{{Id: "i.1", Type: "library-songs"}},
+	})
+	if err != nil {
+		t.Fatalf("Me.AddLibraryTracksToPlaylist returned error: %v", err)
+	}
+
+	want := []applemusic.CreateLibraryPlaylistTrack{{Id: "1", Type: "songs"}, {Id: "i.1", Type: "library-songs"}}
+	if got := s.LibraryPlaylistTracks(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("LibraryPlaylistTracks = %+v, want %+v", got, want)
+	}
+
+	tracks, total, err := client.Me.GetLibraryPlaylistTracks(ctx, id, nil)
+	if err != nil {
+		t.Fatalf("Me.GetLibraryPlaylistTracks returned error: %v", err)
+	}
+	if total != 2 || len(tracks) != 2 || tracks[0].Attributes.Name != "Sunday Bloody Sunday" || tracks[1].Attributes.Name != "Gloria" {
+		t.Errorf("Me.GetLibraryPlaylistTracks = %+v, %v, want the 2 added tracks", tracks, total)
+	}
+
+	if _, err := client.Me.AddLibraryTracksToPlaylist(ctx, "p.404", applemusic.CreateLibraryPlaylistTrackData{}); !errors.Is(err, applemusic.ErrNotFound) {
+		t.Errorf("Me.AddLibraryTracksToPlaylist returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_tokens(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+	s.SetTokens("developer-token", "music-user-token")
+
+	ctx := context.Background()
+
+	client := s.Client()
+	if _, _, err := client.Me.GetStorefront(ctx, nil); err != nil {
+		t.Errorf("Me.GetStorefront returned error: %v", err)
+	}
+
+	noUser := applemusic.NewClient((&applemusic.Transport{Token: "developer-token"}).Client())
+	noUser.BaseURL = client.BaseURL
+	if _, _, err := noUser.Catalog.GetSong(ctx, "us", "1", nil); err != nil {
+		t.Errorf("Catalog.GetSong returned error: %v", err)
+	}
+	if _, _, err := noUser.Me.GetStorefront(ctx, nil); !errors.Is(err, applemusic.ErrForbidden) {
+		t.Errorf("Me.GetStorefront returned error %v, want ErrForbidden", err)
+	}
+
+	badToken := applemusic.NewClient((&applemusic.Transport{Token: "expired"}).Client())
+	badToken.BaseURL = client.BaseURL
+	_, _, err := badToken.Catalog.GetSong(ctx, "us", "1", nil)
+	if _, ok := err.(*applemusic.UnauthorizedError); !ok {
+		t.Errorf("Catalog.GetSong returned error %v, want *UnauthorizedError", err)
+	}
+}
+
+func TestServer_faults(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	client := s.Client()
+	ctx := context.Background()
+
+	s.InjectFault(Fault{Path: "/v1/catalog", StatusCode: http.StatusServiceUnavailable})
+	if _, _, err := client.Catalog.GetSong(ctx, "us", "1", nil); !errors.Is(err, applemusic.ErrUpstreamUnavailable) {
+		t.Errorf("Catalog.GetSong returned error %v, want ErrUpstreamUnavailable", err)
+	}
+	if _, _, err := client.Storefront.GetAll(ctx, nil); err != nil {
+		t.Errorf("Storefront.GetAll returned error: %v", err)
+	}
+	s.ClearFaults()
+
+	s.InjectFault(Fault{StatusCode: http.StatusTooManyRequests, Count: 2, RetryAfter: 1})
+	client.RetryPolicy = &applemusic.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+
+	start := time.Now()
+	if _, _, err := client.Catalog.GetSong(ctx, "us", "1", nil); err != nil {
+		t.Errorf("Catalog.GetSong returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Second {
+		t.Errorf("Catalog.GetSong returned in %v, want to wait for Retry-After twice", elapsed)
+	}
+
+	s.InjectFault(Fault{StatusCode: http.StatusUnauthorized, Count: 1})
+	_, _, err := client.Catalog.GetSong(ctx, "us", "1", nil)
+	if _, ok := err.(*applemusic.UnauthorizedError); !ok {
+		t.Errorf("Catalog.GetSong returned error %v, want *UnauthorizedError", err)
+	}
+
+	s.InjectFault(Fault{})
+	_, resp, err := client.Catalog.GetSong(ctx, "us", "1", nil)
+	if !errors.Is(err, applemusic.ErrUpstreamUnavailable) {
+		t.Errorf("Catalog.GetSong returned error %v, want ErrUpstreamUnavailable", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Catalog.GetSong returned response %+v, want status %d", resp, http.StatusInternalServerError)
+	}
+	s.ClearFaults()
+}
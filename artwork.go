@@ -0,0 +1,234 @@
+package applemusic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ArtworkFormat represents the image format of an artwork.
+type ArtworkFormat string
+
+// The image formats of the artworks.
+const (
+	ArtworkFormatJPEG = ArtworkFormat("jpg")
+	ArtworkFormatPNG  = ArtworkFormat("png")
+	ArtworkFormatWebP = ArtworkFormat("webp")
+)
+
+// ArtworkOptions specifies the size and format of an artwork image.
+type ArtworkOptions struct {
+	// The width and height of the image, at most the Width and Height of the artwork.
+	// A larger size is scaled down to fit the artwork, keeping its aspect ratio.
+	// If one of them is zero, it is derived from the other using the aspect ratio of the artwork.
+	// If both are zero, the maximum size of the artwork is used.
+	Width  int
+	Height int
+
+	// The image format. If empty, the format of the artwork URL is used, or JPEG.
+	Format ArtworkFormat
+}
+
+// FormatURL returns the URL of the artwork image of the size and format,
+// substituting the {w}, {h}, {c} and {f} placeholders of the URL.
+func (a Artwork) FormatURL(width, height int, format ArtworkFormat) string {
+	width, height = a.size(width, height)
+
+	r := strings.NewReplacer(
+		"{w}", strconv.Itoa(width),
+		"{h}", strconv.Itoa(height),
+		"{c}", "bb",
+	)
+	u := r.Replace(a.URL)
+
+	if strings.Contains(u, "{f}") {
+		if format == "" {
+			format = ArtworkFormatJPEG
+		}
+		return strings.Replace(u, "{f}", string(format), -1)
+	}
+	if format != "" {
+		// Replace the extension of the last path segment, such as the jpg of 100x100bb.jpg.
+		slash := strings.LastIndex(u, "/")
+		if dot := strings.LastIndex(u, "."); dot > slash {
+			u = u[:dot+1] + string(format)
+		}
+	}
+	return u
+}
+
+// size returns the size of the image, bounded by the maximum size of the artwork.
+// A size exceeding the artwork is scaled down by a single factor, keeping its aspect ratio.
+func (a Artwork) size(width, height int) (int, int) {
+	if width <= 0 && height <= 0 {
+		return a.Width, a.Height
+	}
+	if a.Width <= 0 || a.Height <= 0 {
+		return width, height
+	}
+	if width <= 0 {
+		width = height * a.Width / a.Height
+	}
+	if height <= 0 {
+		height = width * a.Height / a.Width
+	}
+	if width <= a.Width && height <= a.Height {
+		return width, height
+	}
+
+	// The axis exceeding the artwork the most bounds the image.
+	if width*a.Height >= height*a.Width {
+		return a.Width, divRound(height*a.Width, width)
+	}
+	return divRound(width*a.Height, height), a.Height
+}
+
+// divRound returns the quotient of the positive integers rounded to the nearest integer, at least 1.
+func divRound(n, d int) int {
+	if q := (2*n + d) / (2 * d); q > 0 {
+		return q
+	}
+	return 1
+}
+
+// BackgroundColor returns the parsed BgColor of the artwork.
+func (a Artwork) BackgroundColor() (color.RGBA, error) {
+	return parseHexColor(a.BgColor)
+}
+
+// TextColor returns the parsed TextColor1, TextColor2, TextColor3 or TextColor4 of the artwork, for n from 1 to 4.
+func (a Artwork) TextColor(n int) (color.RGBA, error) {
+	switch n {
+	case 1:
+		return parseHexColor(a.TextColor1)
+	case 2:
+		return parseHexColor(a.TextColor2)
+	case 3:
+		return parseHexColor(a.TextColor3)
+	case 4:
+		return parseHexColor(a.TextColor4)
+	}
+	return color.RGBA{}, fmt.Errorf("applemusic: invalid text color number %d", n)
+}
+
+// parseHexColor parses a color of the RRGGBB hexadecimal form, such as f4f4f4, with an optional leading #.
+func parseHexColor(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{}, fmt.Errorf("applemusic: invalid color %q", s)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("applemusic: invalid color %q", s)
+	}
+	return color.RGBA{R: b[0], G: b[1], B: b[2], A: 0xff}, nil
+}
+
+func (o *ArtworkOptions) url(artwork Artwork) (string, error) {
+	if artwork.URL == "" {
+		return "", errors.New("applemusic: artwork has no URL")
+	}
+	if o == nil {
+		o = &ArtworkOptions{}
+	}
+	return artwork.FormatURL(o.Width, o.Height, o.Format), nil
+}
+
+// DownloadArtwork downloads the artwork image of the size and format specified by opt, and writes it to w.
+// The image is cached by the CacheTransport of the client, if any.
+func (c *Client) DownloadArtwork(ctx context.Context, artwork Artwork, opt *ArtworkOptions, w io.Writer) (*Response, error) {
+	u, err := opt.url(artwork)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, w)
+}
+
+// DownloadArtworks downloads the artwork images of the size and format specified by opt concurrently,
+// and writes them to the files named by the SHA-256 of their URLs in the directory dir.
+// The images already in the directory are not downloaded again.
+// It returns the paths of the files, in the order of the artworks.
+func (c *Client) DownloadArtworks(ctx context.Context, artworks []Artwork, opt *ArtworkOptions, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(artworks))
+	paths := make([]string, len(artworks))
+	for i, artwork := range artworks {
+		u, err := opt.url(artwork)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = u
+		paths[i] = filepath.Join(dir, artworkFilename(u))
+	}
+
+	var pending []int
+	for i := range artworks {
+		if _, err := os.Stat(paths[i]); err != nil {
+			pending = append(pending, i)
+		}
+	}
+
+	_, err := forEachConcurrently(ctx, len(pending), c.BatchConcurrency, func(ctx context.Context, i int) error {
+		return c.downloadFile(ctx, urls[pending[i]], paths[pending[i]])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// downloadFile downloads u to the file at name, writing a temporary file that is renamed on success.
+func (c *Client) downloadFile(ctx context.Context, u, name string) error {
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(name), "tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// artworkFilename returns the file name of the artwork image at u: the SHA-256 of u, with the extension of u.
+func artworkFilename(u string) string {
+	sum := sha256.Sum256([]byte(u))
+	name := hex.EncodeToString(sum[:])
+
+	if parsed, err := url.Parse(u); err == nil {
+		name += path.Ext(parsed.Path)
+	}
+	return name
+}
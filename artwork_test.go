@@ -0,0 +1,247 @@
+package applemusic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestArtwork_FormatURL(t *testing.T) {
+	artwork := Artwork{
+		Width:  1200,
+		Height: 600,
+		URL:    "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/{w}x{h}bb.jpg",
+	}
+	template := Artwork{
+		Width:  1000,
+		Height: 1000,
+		URL:    "https://example.mzstatic.com/image/thumb/Music/v4/b.png/{w}x{h}{c}.{f}",
+	}
+
+	testCases := []struct {
+		artwork       Artwork
+		width, height int
+		format        ArtworkFormat
+		want          string
+	}{
+		{artwork, 300, 150, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/300x150bb.jpg"},
+		{artwork, 0, 0, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/1200x600bb.jpg"},
+		{artwork, 400, 0, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/400x200bb.jpg"},
+		{artwork, 0, 100, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/200x100bb.jpg"},
+		{artwork, 5000, 5000, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/600x600bb.jpg"},
+		{artwork, 5000, 0, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/1200x600bb.jpg"},
+		{artwork, 6000, 1000, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/1200x200bb.jpg"},
+		{artwork, 1000, 1000, "", "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/600x600bb.jpg"},
+		{artwork, 300, 150, ArtworkFormatPNG, "https://example.mzstatic.com/image/thumb/Music/v4/a.jpg/300x150bb.png"},
+		{template, 100, 100, "", "https://example.mzstatic.com/image/thumb/Music/v4/b.png/100x100bb.jpg"},
+		{template, 100, 100, ArtworkFormatWebP, "https://example.mzstatic.com/image/thumb/Music/v4/b.png/100x100bb.webp"},
+	}
+	for _, tc := range testCases {
+		if got := tc.artwork.FormatURL(tc.width, tc.height, tc.format); got != tc.want {
+			t.Errorf("FormatURL(%v, %v, %q) = %v, want %v", tc.width, tc.height, tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestArtwork_size(t *testing.T) {
+	testCases := []struct {
+		artwork               Artwork
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{Artwork{Width: 3000, Height: 2000}, 5000, 0, 3000, 2000},
+		{Artwork{Width: 1000, Height: 3000}, 4000, 0, 1000, 3000},
+		{Artwork{Width: 1000, Height: 3000}, 4000, 1000, 1000, 250},
+		{Artwork{Width: 1000, Height: 3000}, 0, 6000, 1000, 3000},
+		{Artwork{Width: 1000, Height: 3000}, 500, 0, 500, 1500},
+		{Artwork{}, 500, 400, 500, 400},
+	}
+	for _, tc := range testCases {
+		width, height := tc.artwork.size(tc.width, tc.height)
+		if width != tc.wantWidth || height != tc.wantHeight {
+			t.Errorf("%+v.size(%d, %d) = %dx%d, want %dx%d", tc.artwork, tc.width, tc.height, width, height, tc.wantWidth, tc.wantHeight)
+		}
+		// The aspect ratio of the requested size is kept.
+		if tc.width > 0 && tc.height > 0 && width*tc.height != height*tc.width {
+			t.Errorf("%+v.size(%d, %d) = %dx%d, want the aspect ratio of the requested size", tc.artwork, tc.width, tc.height, width, height)
+		}
+	}
+}
+
+func TestArtwork_colors(t *testing.T) {
+	artwork := Artwork{
+		BgColor:    "f4f4f4",
+		TextColor1: "0a0b0c",
+		TextColor4: "#FFFFFF",
+		TextColor3: "nope",
+	}
+
+	if got, err := artwork.BackgroundColor(); err != nil || got != (color.RGBA{0xf4, 0xf4, 0xf4, 0xff}) {
+		t.Errorf("BackgroundColor = %v, %v, want f4f4f4", got, err)
+	}
+	if got, err := artwork.TextColor(1); err != nil || got != (color.RGBA{0x0a, 0x0b, 0x0c, 0xff}) {
+		t.Errorf("TextColor(1) = %v, %v, want 0a0b0c", got, err)
+	}
+	if got, err := artwork.TextColor(4); err != nil || got != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("TextColor(4) = %v, %v, want ffffff", got, err)
+	}
+	for _, n := range []int{0, 2, 3, 5} {
+		if _, err := artwork.TextColor(n); err == nil {
+			t.Errorf("TextColor(%v) returned no error", n)
+		}
+	}
+}
+
+func TestClient_DownloadArtwork(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/image/a.jpg/100x100bb.png", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte("png"))
+	})
+
+	artwork := Artwork{Width: 1000, Height: 1000, URL: server.URL + "/image/a.jpg/{w}x{h}bb.jpg"}
+
+	var buf bytes.Buffer
+	_, err := client.DownloadArtwork(context.Background(), artwork, &ArtworkOptions{Width: 100, Format: ArtworkFormatPNG}, &buf)
+	if err != nil {
+		t.Fatalf("DownloadArtwork returned error: %v", err)
+	}
+	if got, want := buf.String(), "png"; got != want {
+		t.Errorf("DownloadArtwork wrote %q, want %q", got, want)
+	}
+
+	if _, err := client.DownloadArtwork(context.Background(), Artwork{}, nil, &buf); err == nil {
+		t.Error("Expected an error for an artwork without URL.")
+	}
+}
+
+func TestClient_DownloadArtwork_noCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tp := &Transport{Token: "developer-token", MusicUserToken: "music-user-token"}
+	client = NewClient(tp.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond}
+
+	mux.HandleFunc("/v1/catalog/us/songs/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" || r.Header.Get("Music-User-Token") == "" {
+			t.Errorf("API request headers = %v, want the tokens", r.Header)
+		}
+		fmt.Fprint(w, `{"data": []}`)
+	})
+
+	var cdnRequests int32
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&cdnRequests, 1)
+		for _, name := range []string{"Authorization", "Music-User-Token"} {
+			if v := r.Header.Get(name); v != "" {
+				t.Errorf("CDN request header %s = %q, want none", name, v)
+			}
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer cdn.Close()
+
+	artwork := Artwork{Width: 100, Height: 100, URL: cdn.URL + "/image/{w}x{h}bb.jpg"}
+	var buf bytes.Buffer
+	if _, err := client.DownloadArtwork(context.Background(), artwork, nil, &buf); err == nil {
+		t.Error("DownloadArtwork returned no error for a Too Many Requests response")
+	}
+	// The retries are for the API, not for the CDN.
+	if n := atomic.LoadInt32(&cdnRequests); n != 1 {
+		t.Errorf("CDN received %d requests, want 1", n)
+	}
+
+	if _, _, err := client.Catalog.GetSong(context.Background(), "us", "1", nil); err != nil {
+		t.Errorf("Catalog.GetSong returned error: %v", err)
+	}
+}
+
+func TestClient_DownloadArtworks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc("/image/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+
+	artworks := []Artwork{
+		{Width: 100, Height: 100, URL: server.URL + "/image/a/{w}x{h}bb.jpg"},
+		{Width: 100, Height: 100, URL: server.URL + "/image/b/{w}x{h}bb.jpg"},
+		{Width: 100, Height: 100, URL: server.URL + "/image/c/{w}x{h}bb.jpg"},
+	}
+	dir, err := ioutil.TempDir("", "applemusic")
+	if err != nil {
+		t.Fatalf("TempDir returned error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	opt := &ArtworkOptions{Width: 50, Height: 50}
+	paths, err := client.DownloadArtworks(context.Background(), artworks, opt, dir)
+	if err != nil {
+		t.Fatalf("DownloadArtworks returned error: %v", err)
+	}
+
+	var got []string
+	for _, p := range paths {
+		if filepath.Dir(p) != dir || filepath.Ext(p) != ".jpg" {
+			t.Errorf("DownloadArtworks path = %v, want a .jpg file in %v", p, dir)
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(b))
+	}
+	want := []string{"/image/a/50x50bb.jpg", "/image/b/50x50bb.jpg", "/image/c/50x50bb.jpg"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DownloadArtworks wrote %v, want %v", got, want)
+	}
+
+	// The images already downloaded are not requested again.
+	if _, err := client.DownloadArtworks(context.Background(), artworks, opt, dir); err != nil {
+		t.Fatalf("DownloadArtworks returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("DownloadArtworks sent %d requests, want 3", got)
+	}
+}
+
+func TestClient_DownloadArtworks_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/image/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	dir, err := ioutil.TempDir("", "applemusic")
+	if err != nil {
+		t.Fatalf("TempDir returned error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	artworks := []Artwork{{Width: 100, Height: 100, URL: server.URL + "/image/a/{w}x{h}bb.jpg"}}
+	if _, err := client.DownloadArtworks(context.Background(), artworks, nil, dir); err == nil {
+		t.Fatal("Expected HTTP 404 error.")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("DownloadArtworks left %d files, want none", len(files))
+	}
+}
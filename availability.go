@@ -0,0 +1,188 @@
+package applemusic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AvailabilityOptions specifies the resources and the storefronts checked by Client.GetAvailability.
+type AvailabilityOptions struct {
+	// The type of the resources: ResourceTypeSongs, the default, or ResourceTypeAlbums.
+	Type ResourceType
+
+	// The catalog identifiers of the resources.
+	Ids []string
+
+	// The ISRCs of the songs.
+	Isrcs []string
+
+	// The storefronts to check. If empty, all the storefronts returned by StorefrontsService.GetAll are checked.
+	Storefronts []string
+}
+
+// Availability is the availability matrix of catalog resources across storefronts.
+type Availability struct {
+	// The checked storefronts, in order.
+	Storefronts []string
+
+	// The catalog identifiers and ISRCs of the resources, in order.
+	Keys []string
+
+	// The storefront-local identifiers of the resources by key and storefront, such as Hits["1440833098"]["jp"].
+	// The identifier is the one to play the resource in the storefront, which may differ from the requested one.
+	// An ISRC may have several songs in a storefront, such as the same recording on several albums.
+	Hits map[string]map[string][]string
+}
+
+// Available reports whether the resource of key is available in the storefront.
+func (a *Availability) Available(key, storefront string) bool {
+	return len(a.Hits[key][storefront]) > 0
+}
+
+// LocalIds returns the identifiers of the resource of key in the storefront, or nil if it is not available there.
+func (a *Availability) LocalIds(key, storefront string) []string {
+	return a.Hits[key][storefront]
+}
+
+// AvailableIn returns the storefronts where the resource of key is available, in the order of Storefronts.
+func (a *Availability) AvailableIn(key string) []string {
+	var storefronts []string
+	for _, storefront := range a.Storefronts {
+		if a.Available(key, storefront) {
+			storefronts = append(storefronts, storefront)
+		}
+	}
+	return storefronts
+}
+
+// GetAvailability fetches the songs or albums of opt in every storefront, concurrently with at most
+// BatchConcurrency storefronts at a time, and returns where they are available.
+// The batches of a storefront are fetched one at a time, so that at most BatchConcurrency requests are in flight.
+func (c *Client) GetAvailability(ctx context.Context, opt *AvailabilityOptions) (*Availability, error) {
+	if opt == nil {
+		opt = &AvailabilityOptions{}
+	}
+	typ := opt.Type
+	switch typ {
+	case "":
+		typ = ResourceTypeSongs
+	case ResourceTypeSongs, ResourceTypeAlbums:
+	default:
+		return nil, &InvalidOptionError{Option: "AvailabilityOptions.Type", Reason: fmt.Sprintf("unsupported type %q", typ)}
+	}
+	if typ == ResourceTypeAlbums && len(opt.Isrcs) > 0 {
+		return nil, &InvalidOptionError{Option: "AvailabilityOptions.Isrcs", Reason: "only songs have ISRCs"}
+	}
+	if len(opt.Ids) == 0 && len(opt.Isrcs) == 0 {
+		return nil, &InvalidOptionError{Option: "AvailabilityOptions.Ids", Reason: "must not be empty without ISRCs"}
+	}
+
+	storefronts := opt.Storefronts
+	if len(storefronts) == 0 {
+		all, _, err := c.Storefront.GetAll(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.CollectAll(ctx, all, nil); err != nil {
+			return nil, err
+		}
+		for _, storefront := range all.Data {
+			storefronts = append(storefronts, storefront.Id)
+		}
+	}
+
+	a := &Availability{
+		Storefronts: storefronts,
+		Keys:        append(append([]string{}, opt.Ids...), opt.Isrcs...),
+		Hits:        map[string]map[string][]string{},
+	}
+	for _, key := range a.Keys {
+		a.Hits[key] = map[string][]string{}
+	}
+
+	var mu sync.Mutex
+	_, err := forEachConcurrently(ctx, len(storefronts), c.BatchConcurrency, func(ctx context.Context, i int) error {
+		hits, err := c.getStorefrontHits(ctx, storefronts[i], typ, opt.Ids, opt.Isrcs)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for key, ids := range hits {
+			if byStorefront, ok := a.Hits[key]; ok {
+				byStorefront[storefronts[i]] = ids
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// getStorefrontHits returns the local identifiers of the resources found in the storefront, by requested key.
+// The storefronts without any of the resources may respond Not Found.
+func (c *Client) getStorefrontHits(ctx context.Context, storefront string, typ ResourceType, ids, isrcs []string) (map[string][]string, error) {
+	hits := map[string][]string{}
+
+	if typ == ResourceTypeAlbums {
+		albums, _, err := c.Catalog.GetAlbumsByIds(ctx, storefront, ids, nil)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			for _, album := range albums.Data {
+				hits[album.Id] = append(hits[album.Id], localId(album.Id, album.Attributes.PlayParams))
+			}
+		}
+		return hits, nil
+	}
+
+	if len(ids) > 0 {
+		songs, _, err := c.Catalog.GetSongsByIds(ctx, storefront, ids, nil)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			for _, song := range songs.Data {
+				hits[song.Id] = append(hits[song.Id], localId(song.Id, song.Attributes.PlayParams))
+			}
+		}
+	}
+
+	if len(isrcs) > 0 {
+		songs, _, err := c.Catalog.GetSongsByIsrcs(ctx, storefront, isrcs, nil)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			// The ISRCs are matched case-insensitively, and reported in the case they were requested.
+			keys := map[string]string{}
+			for _, isrc := range isrcs {
+				keys[strings.ToUpper(isrc)] = isrc
+			}
+			for _, song := range songs.Data {
+				if key, ok := keys[strings.ToUpper(song.Attributes.ISRC)]; ok {
+					hits[key] = append(hits[key], localId(song.Id, song.Attributes.PlayParams))
+				}
+			}
+		}
+	}
+	return hits, nil
+}
+
+// localId returns the identifier to play a resource, the identifier of its play parameters if any.
+func localId(id string, playParams *PlayParameters) string {
+	if playParams != nil && playParams.Id != "" {
+		return playParams.Id
+	}
+	return id
+}
@@ -0,0 +1,184 @@
+package applemusic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_GetAvailability_songs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/storefronts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"id": "gb", "type": "storefronts"}, {"id": "jp", "type": "storefronts"}, {"id": "us", "type": "storefronts"}]}`)
+	})
+	mux.HandleFunc("/v1/catalog/us/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("filter[isrc]") != "" {
+			testFormValues(t, r, values{"filter[isrc]": "gbum71029604"})
+			fmt.Fprint(w, `{"data": [
+    {"id": "1440833098", "type": "songs", "attributes": {"isrc": "GBUM71029604"}},
+    {"id": "1440833099", "type": "songs", "attributes": {"isrc": "GBUM71029604"}}
+]}`)
+			return
+		}
+		testFormValues(t, r, values{"ids": "203709340,201281527"})
+		fmt.Fprint(w, `{"data": [
+    {"id": "203709340", "type": "songs", "attributes": {"playParams": {"id": "203709340", "kind": "song"}}},
+    {"id": "201281527", "type": "songs"}
+]}`)
+	})
+	mux.HandleFunc("/v1/catalog/jp/songs", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("filter[isrc]") != "" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [
+    {"id": "203709340", "type": "songs", "attributes": {"playParams": {"id": "1441164426", "kind": "song"}}},
+    {"id": "999", "type": "songs"}
+]}`)
+	})
+	mux.HandleFunc("/v1/catalog/gb/songs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": [{"status": "404", "title": "Not Found"}]}`)
+	})
+
+	got, err := client.GetAvailability(context.Background(), &AvailabilityOptions{
+		Ids:   []string{"203709340", "201281527"},
+		Isrcs: []string{"gbum71029604"},
+	})
+	if err != nil {
+		t.Fatalf("GetAvailability returned error: %v", err)
+	}
+
+	want := &Availability{
+		Storefronts: []string{"gb", "jp", "us"},
+		Keys:        []string{"203709340", "201281527", "gbum71029604"},
+		Hits: map[string]map[string][]string{
+			"203709340":    {"jp": {"1441164426"}, "us": {"203709340"}},
+			"201281527":    {"us": {"201281527"}},
+			"gbum71029604": {"us": {"1440833098", "1440833099"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAvailability = %+v, want %+v", got, want)
+	}
+	if in := got.AvailableIn("203709340"); !reflect.DeepEqual(in, []string{"jp", "us"}) {
+		t.Errorf("AvailableIn = %v, want [jp us]", in)
+	}
+	if got.Available("201281527", "jp") || got.LocalIds("201281527", "jp") != nil {
+		t.Errorf("Available in jp = true, want false")
+	}
+}
+
+func TestClient_GetAvailability_albums(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"ids": "1440833083"})
+		fmt.Fprint(w, `{"data": [{"id": "1440833083", "type": "albums"}]}`)
+	})
+	mux.HandleFunc("/v1/catalog/jp/albums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": []}`)
+	})
+
+	got, err := client.GetAvailability(context.Background(), &AvailabilityOptions{
+		Type:        ResourceTypeAlbums,
+		Ids:         []string{"1440833083"},
+		Storefronts: []string{"us", "jp"},
+	})
+	if err != nil {
+		t.Fatalf("GetAvailability returned error: %v", err)
+	}
+	if in := got.AvailableIn("1440833083"); !reflect.DeepEqual(in, []string{"us"}) {
+		t.Errorf("AvailableIn = %v, want [us]", in)
+	}
+}
+
+func TestClient_GetAvailability_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/songs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": []}`)
+	})
+	mux.HandleFunc("/v1/catalog/jp/songs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors": [{"status": "401", "title": "Unauthorized"}]}`)
+	})
+
+	_, err := client.GetAvailability(context.Background(), &AvailabilityOptions{Ids: []string{"1"}, Storefronts: []string{"us", "jp"}})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetAvailability returned error %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestClient_GetAvailability_concurrency(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight, calls int
+	mux.HandleFunc("/v1/catalog/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"data": []}`)
+	})
+	client.BatchConcurrency = 2
+
+	var ids []string
+	for i := 0; i < 2*maxAlbumIdsPerRequest+1; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	_, err := client.GetAvailability(context.Background(), &AvailabilityOptions{
+		Type:        ResourceTypeAlbums,
+		Ids:         ids,
+		Storefronts: []string{"gb", "jp", "us"},
+	})
+	if err != nil {
+		t.Fatalf("GetAvailability returned error: %v", err)
+	}
+
+	if calls != 9 {
+		t.Errorf("Server called %d times, want 9", calls)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("GetAvailability sent %d concurrent requests, want at most 2", maxInFlight)
+	}
+}
+
+func TestClient_GetAvailability_invalidOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tests := []*AvailabilityOptions{
+		nil,
+		{Type: ResourceTypeArtists, Ids: []string{"1"}},
+		{Type: ResourceTypeAlbums, Isrcs: []string{"GBUM71029604"}},
+	}
+	for _, opt := range tests {
+		if _, err := client.GetAvailability(context.Background(), opt); !errors.Is(err, ErrBadParameter) {
+			t.Errorf("GetAvailability(%+v) returned error %v, want ErrBadParameter", opt, err)
+		}
+	}
+}
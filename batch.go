@@ -0,0 +1,196 @@
+package applemusic
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// The maximum number of identifiers per request of the by-ids and by-ISRCs methods.
+const (
+	maxSongIdsPerRequest       = 300
+	maxAlbumIdsPerRequest      = 100
+	maxMusicVideoIdsPerRequest = 100
+	maxStationIdsPerRequest    = 100
+	maxIdsPerRequest           = 25 // Activities, artists, curators, genres, playlists and storefronts.
+	maxLibraryIdsPerRequest    = 25 // Library resources, ratings and recommendations.
+	maxIsrcsPerRequest         = 25
+	maxUpcsPerRequest          = 25
+	maxEquivalentsPerRequest   = 25
+)
+
+const defaultBatchConcurrency = 4
+
+// getInBatches fetches the resources identified by keys into v, a pointer to a collection type,
+// splitting the keys into batches of size that are fetched concurrently.
+// The makeURL function returns the URL of the request of a batch.
+// The Data of the batches are merged in the order of the keys.
+//
+// It returns the response of the first batch, or the error and response of the first failed batch.
+func (c *Client) getInBatches(ctx context.Context, keys []string, size int, v interface{}, makeURL func(batch []string) (string, error)) (*Response, error) {
+	if len(keys) <= size {
+		return c.getBatch(ctx, keys, v, makeURL)
+	}
+
+	var batches [][]string
+	for len(keys) > 0 {
+		n := size
+		if len(keys) < n {
+			n = len(keys)
+		}
+		batches = append(batches, keys[:n])
+		keys = keys[n:]
+	}
+
+	typ := reflect.TypeOf(v).Elem()
+	pages := make([]interface{}, len(batches))
+	responses := make([]*Response, len(batches))
+	i, err := forEachConcurrently(ctx, len(batches), c.BatchConcurrency, func(ctx context.Context, i int) error {
+		page := reflect.New(typ).Interface()
+		resp, err := c.getBatch(ctx, batches[i], page, makeURL)
+		responses[i], pages[i] = resp, page
+		return err
+	})
+	if err != nil {
+		return responses[i], err
+	}
+
+	reflect.ValueOf(v).Elem().Set(reflect.ValueOf(pages[0]).Elem())
+	dst := collection{v: reflect.ValueOf(v)}.data()
+	for _, page := range pages[1:] {
+		src := collection{v: reflect.ValueOf(page)}.data()
+		dst.Set(reflect.AppendSlice(dst, src))
+	}
+
+	return responses[0], nil
+}
+
+// forEachConcurrently calls fn with the indexes from 0 to n-1 concurrently, at most concurrency calls at a time,
+// or defaultBatchConcurrency if it is not positive. When a call fails, the context of the others is canceled.
+// The calls made within a call of fn, such as the batches of a storefront fetched by Client.GetAvailability,
+// are made one at a time, so that the concurrency bounds all the requests in flight.
+//
+// It returns the index and the error of the call that actually failed, rather than the cancellation of the others.
+func forEachConcurrently(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if ctx.Value(fanOutKey{}) != nil {
+		concurrency = 1
+	}
+	ctx = context.WithValue(ctx, fanOutKey{}, true)
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if errs[i] = ctx.Err(); errs[i] != nil {
+				return
+			}
+			if errs[i] = fn(ctx, i); errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil && err != context.Canceled {
+			return i, err
+		}
+	}
+	for i, err := range errs {
+		if err != nil {
+			return i, err
+		}
+	}
+	return 0, nil
+}
+
+// fanOutKey is the context key marking the calls of forEachConcurrently.
+type fanOutKey struct{}
+
+func (c *Client) getBatch(ctx context.Context, batch []string, v interface{}, makeURL func(batch []string) (string, error)) (*Response, error) {
+	u, err := makeURL(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, v)
+}
+
+// MissingIds returns the identifiers of ids that are not in the Data of collection,
+// a pointer to a collection type such as the *Songs returned by CatalogService.GetSongsByIds.
+func MissingIds(ids []string, collection interface{}) []string {
+	return missing(ids, collection, func(item reflect.Value) string {
+		return stringField(item, "Id")
+	})
+}
+
+// MissingIsrcs returns the ISRCs of isrcs that are not the ISRC of any resource in the Data of collection,
+// a pointer to a collection type such as the *Songs returned by CatalogService.GetSongsByIsrcs.
+func MissingIsrcs(isrcs []string, collection interface{}) []string {
+	return missing(isrcs, collection, func(item reflect.Value) string {
+		attributes := item.FieldByName("Attributes")
+		if !attributes.IsValid() || attributes.Kind() != reflect.Struct {
+			return ""
+		}
+		return stringField(attributes, "ISRC")
+	})
+}
+
+// MissingUpcs returns the UPCs of upcs that are not the UPC of any resource in the Data of collection,
+// a pointer to a collection type such as the *Albums returned by CatalogService.GetAlbumsByUpcs.
+func MissingUpcs(upcs []string, collection interface{}) []string {
+	return missing(upcs, collection, func(item reflect.Value) string {
+		attributes := item.FieldByName("Attributes")
+		if !attributes.IsValid() || attributes.Kind() != reflect.Struct {
+			return ""
+		}
+		return stringField(attributes, "UPC")
+	})
+}
+
+func missing(keys []string, page interface{}, keyOf func(item reflect.Value) string) []string {
+	found := map[string]bool{}
+	if c, err := newCollection(page); err == nil {
+		data := c.data()
+		for i := 0; i < data.Len(); i++ {
+			item := reflect.Indirect(data.Index(i))
+			if item.Kind() == reflect.Struct {
+				found[keyOf(item)] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if !found[key] {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+func stringField(v reflect.Value, name string) string {
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
@@ -0,0 +1,156 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCatalogService_GetSongsByIsrcs_batches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var batches []int
+	mux.HandleFunc("/v1/catalog/us/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		isrcs := strings.Split(r.URL.Query().Get("filter[isrc]"), ",")
+
+		mu.Lock()
+		batches = append(batches, len(isrcs))
+		mu.Unlock()
+
+		var data []string
+		for _, isrc := range isrcs {
+			if isrc == "MISSING" {
+				continue
+			}
+			data = append(data, fmt.Sprintf(`{"id":"%s","type":"songs","attributes":{"isrc":"%s"}}`, strings.TrimPrefix(isrc, "ISRC"), isrc))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	})
+
+	var isrcs []string
+	for i := 0; i < 60; i++ {
+		isrcs = append(isrcs, fmt.Sprintf("ISRC%d", i))
+	}
+	isrcs[30] = "MISSING"
+
+	got, _, err := client.Catalog.GetSongsByIsrcs(context.Background(), "us", isrcs, nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetSongsByIsrcs returned error: %v", err)
+	}
+
+	if len(batches) != 3 {
+		t.Errorf("Catalog.GetSongsByIsrcs sent %d requests, want 3", len(batches))
+	}
+	for _, n := range batches {
+		if n > maxIsrcsPerRequest {
+			t.Errorf("Catalog.GetSongsByIsrcs sent a batch of %d ISRCs, want at most %d", n, maxIsrcsPerRequest)
+		}
+	}
+
+	var gotIsrcs []string
+	for _, song := range got.Data {
+		gotIsrcs = append(gotIsrcs, song.Attributes.ISRC)
+	}
+	wantIsrcs := append(append([]string{}, isrcs[:30]...), isrcs[31:]...)
+	if !reflect.DeepEqual(gotIsrcs, wantIsrcs) {
+		t.Errorf("Catalog.GetSongsByIsrcs = %v, want %v", gotIsrcs, wantIsrcs)
+	}
+
+	if got, want := MissingIsrcs(isrcs, got), []string{"MISSING"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingIsrcs = %v, want %v", got, want)
+	}
+}
+
+func TestMeService_GetLibrarySongsByIds_batches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var batches []int
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"ids": r.URL.Query().Get("ids"), "include": "catalog"})
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+
+		mu.Lock()
+		batches = append(batches, len(ids))
+		mu.Unlock()
+
+		var data []string
+		for _, id := range ids {
+			data = append(data, fmt.Sprintf(`{"id":"%s","type":"library-songs"}`, id))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	})
+
+	var ids []string
+	for i := 0; i < 60; i++ {
+		ids = append(ids, fmt.Sprintf("i.%d", i))
+	}
+
+	got, _, err := client.Me.GetLibrarySongsByIds(context.Background(), ids, &Options{Include: []Relationship{RelationshipCatalog}})
+	if err != nil {
+		t.Fatalf("Me.GetLibrarySongsByIds returned error: %v", err)
+	}
+
+	if len(batches) != 3 {
+		t.Errorf("Me.GetLibrarySongsByIds sent %d requests, want 3", len(batches))
+	}
+	for _, n := range batches {
+		if n > maxLibraryIdsPerRequest {
+			t.Errorf("Me.GetLibrarySongsByIds sent a batch of %d ids, want at most %d", n, maxLibraryIdsPerRequest)
+		}
+	}
+
+	var gotIds []string
+	for _, song := range got.Data {
+		gotIds = append(gotIds, song.Id)
+	}
+	if !reflect.DeepEqual(gotIds, ids) {
+		t.Errorf("Me.GetLibrarySongsByIds = %v, want %v", gotIds, ids)
+	}
+}
+
+func TestCatalogService_GetArtistsByIds_batchError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/artists", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Query().Get("ids"), "25,") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	var ids []string
+	for i := 0; i < 50; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+
+	_, resp, err := client.Catalog.GetArtistsByIds(context.Background(), "us", ids, nil)
+	if err == nil {
+		t.Fatal("Expected HTTP 404 error.")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Catalog.GetArtistsByIds response = %+v, want 404", resp)
+	}
+}
+
+func TestMissingIds(t *testing.T) {
+	albums := &Albums{Data: []Album{{Id: "1"}, {Id: "3"}}}
+
+	if got, want := MissingIds([]string{"1", "2", "3", "4"}, albums), []string{"2", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingIds = %v, want %v", got, want)
+	}
+	if got := MissingIds([]string{"1", "3"}, albums); got != nil {
+		t.Errorf("MissingIds = %v, want nil", got)
+	}
+}
@@ -0,0 +1,273 @@
+package applemusic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheHeader is the header set on the responses served from the cache of a CacheTransport.
+const CacheHeader = "X-From-Cache"
+
+// Cache is the interface of the storage backends used by CacheTransport.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Cache interface {
+	// Get returns the value stored for the key, if any.
+	Get(key string) ([]byte, bool)
+
+	// Set stores the value for the key.
+	Set(key string, value []byte)
+
+	// Delete removes the value stored for the key.
+	Delete(key string)
+}
+
+// CacheTransport is an http.RoundTripper that caches the responses of GET requests,
+// honoring the Cache-Control, Expires and ETag headers of the responses.
+// Fresh responses are served from the cache, stale responses with an ETag are revalidated with If-None-Match.
+//
+// The cache key is the request URL, which carries the storefront and the language, and the Accept-Language header.
+// The responses of /v1/me endpoints are keyed by the Music-User-Token as well, and are never cached without one.
+// Therefore CacheTransport must be placed under Transport, which sets that header:
+//
+//	tp := applemusic.Transport{
+//		Token:     "APPLE_MUSIC_API_TOKEN",
+//		Transport: &applemusic.CacheTransport{Cache: applemusic.NewMemoryCache(1000)},
+//	}
+type CacheTransport struct {
+	// Cache is the storage backend of the responses.
+	Cache Cache
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	now func() time.Time // Used by tests to control the clock.
+}
+
+// cachedResponse is the representation of a response stored in the Cache.
+type cachedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"storedAt"`
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, ok := cacheKey(req)
+	if !ok || t.Cache == nil {
+		return t.transport().RoundTrip(req)
+	}
+
+	now := t.clock()
+	cached := t.load(key)
+	if cached != nil && cached.fresh(now) {
+		return cached.response(req), nil
+	}
+
+	if etag := cachedETag(cached); etag != "" && req.Header.Get("If-None-Match") == "" {
+		req = cloneRequest(req) // per RoundTrip contract
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		for _, h := range []string{"Cache-Control", "Date", "ETag", "Expires"} {
+			if v := resp.Header.Get(h); v != "" {
+				cached.Header.Set(h, v)
+			}
+		}
+		cached.Header.Del("Age")
+		cached.StoredAt = now
+		t.store(key, cached)
+		return cached.response(req), nil
+	}
+
+	if resp.StatusCode == http.StatusOK && storable(resp.Header) {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		t.store(key, &cachedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       body,
+			StoredAt:   now,
+		})
+	}
+
+	return resp, nil
+}
+
+// Client returns an *http.Client that makes requests through the cache.
+func (t *CacheTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *CacheTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (t *CacheTransport) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func (t *CacheTransport) load(key string) *cachedResponse {
+	data, ok := t.Cache.Get(key)
+	if !ok {
+		return nil
+	}
+	cached := &cachedResponse{}
+	if err := json.Unmarshal(data, cached); err != nil || cached.Header == nil {
+		// An invalid entry, or one written by hand without headers, is a miss.
+		t.Cache.Delete(key)
+		return nil
+	}
+	return cached
+}
+
+func (t *CacheTransport) store(key string, cached *cachedResponse) {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	t.Cache.Set(key, data)
+}
+
+// cacheKey returns the cache key of the request, or false if the request must not be cached.
+func cacheKey(req *http.Request) (string, bool) {
+	if req.Method != "GET" || req.Header.Get("Range") != "" {
+		return "", false
+	}
+
+	key := req.URL.String()
+	if lang := req.Header.Get("Accept-Language"); lang != "" {
+		key += " " + lang
+	}
+
+	if p := req.URL.Path; p == "/v1/me" || strings.HasPrefix(p, "/v1/me/") {
+		userToken := req.Header.Get("Music-User-Token")
+		if userToken == "" {
+			return "", false
+		}
+		sum := sha256.Sum256([]byte(userToken))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+
+	return key, true
+}
+
+func cachedETag(cached *cachedResponse) string {
+	if cached == nil {
+		return ""
+	}
+	return cached.Header.Get("ETag")
+}
+
+// cacheControl parses the directives of the Cache-Control header.
+func cacheControl(h http.Header) map[string]string {
+	directives := map[string]string{}
+	for _, part := range strings.Split(h.Get("Cache-Control"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if i := strings.IndexByte(part, '='); i >= 0 {
+			directives[strings.ToLower(part[:i])] = strings.Trim(part[i+1:], `"`)
+		} else {
+			directives[strings.ToLower(part)] = ""
+		}
+	}
+	return directives
+}
+
+// storable reports whether a response with the headers can be stored and later served or revalidated.
+func storable(h http.Header) bool {
+	cc := cacheControl(h)
+	if _, ok := cc["no-store"]; ok {
+		return false
+	}
+	if h.Get("ETag") != "" || h.Get("Expires") != "" {
+		return true
+	}
+	_, ok := cc["max-age"]
+	return ok
+}
+
+// fresh reports whether the cached response can be served without revalidation at now.
+func (c *cachedResponse) fresh(now time.Time) bool {
+	cc := cacheControl(c.Header)
+	if _, ok := cc["no-cache"]; ok {
+		return false
+	}
+
+	var lifetime time.Duration
+	if v, ok := cc["max-age"]; ok {
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			return false
+		}
+		lifetime = time.Duration(seconds) * time.Second
+	} else if v := c.Header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return false
+		}
+		date, err := http.ParseTime(c.Header.Get("Date"))
+		if err != nil {
+			date = c.StoredAt
+		}
+		lifetime = expires.Sub(date)
+	} else {
+		return false
+	}
+
+	age := now.Sub(c.StoredAt)
+	if seconds, err := strconv.Atoi(c.Header.Get("Age")); err == nil {
+		age += time.Duration(seconds) * time.Second
+	}
+
+	return age < lifetime
+}
+
+// response returns an *http.Response for the request built from the cached response.
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	header := make(http.Header, len(c.Header)+1)
+	for k, s := range c.Header {
+		header[k] = append([]string(nil), s...)
+	}
+	header.Set(CacheHeader, "1")
+
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
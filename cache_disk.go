@@ -0,0 +1,61 @@
+package applemusic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache is a Cache that stores each entry in a file of a directory.
+// It is safe for concurrent use by multiple goroutines and processes sharing the directory.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache that stores its entries in dir, which is created if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file path of the key, the keys contain URLs that are not valid file names.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the value stored for the key, if any.
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores the value for the key. The file is written atomically, errors are ignored.
+func (c *DiskCache) Set(key string, value []byte) {
+	f, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(value)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+}
+
+// Delete removes the value stored for the key.
+func (c *DiskCache) Delete(key string) {
+	_ = os.Remove(c.path(key))
+}
@@ -0,0 +1,36 @@
+package applemusic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "applemusic")
+	if err != nil {
+		t.Fatalf("TempDir returned error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewDiskCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+
+	key := "https://api.music.apple.com/v1/catalog/us/songs/1?l=fr"
+	if _, ok := c.Get(key); ok {
+		t.Error("Get found an entry in an empty cache")
+	}
+
+	c.Set(key, []byte("value"))
+	if got, ok := c.Get(key); !ok || string(got) != "value" {
+		t.Errorf("Get = %q, %v, want value, true", got, ok)
+	}
+
+	c.Delete(key)
+	if _, ok := c.Get(key); ok {
+		t.Error("Get found a deleted entry")
+	}
+}
@@ -0,0 +1,34 @@
+package applemusic
+
+import (
+	"testing"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	if got, ok := c.Get("a"); !ok || string(got) != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1, true", got, ok)
+	}
+
+	// b is the least recently used entry.
+	c.Set("c", []byte("3"))
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found an evicted entry")
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+
+	c.Set("a", []byte("4"))
+	if got, _ := c.Get("a"); string(got) != "4" {
+		t.Errorf("Get(a) = %q, want 4", got)
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) found a deleted entry")
+	}
+}
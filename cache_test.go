@@ -0,0 +1,210 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// setupCache configures the test client to make requests through a CacheTransport under a Transport.
+func setupCache(musicUserToken string) *CacheTransport {
+	ct := &CacheTransport{Cache: NewMemoryCache(0)}
+	tp := &Transport{Token: "TOKEN", MusicUserToken: musicUserToken, Transport: ct}
+	baseURL := client.BaseURL
+	client = NewClient(tp.Client())
+	client.BaseURL = baseURL
+	return ct
+}
+
+func getCached(t *testing.T, u string) (string, *Response) {
+	req, _ := client.NewRequest("GET", u, nil)
+	body := new(struct{ A string })
+	resp, err := client.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	return body.A, resp
+}
+
+func TestCacheTransport_maxAge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/catalog/us/songs/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprintf(w, `{"A":"%d"}`, calls)
+	})
+
+	now := time.Now()
+	ct := setupCache("")
+	ct.now = func() time.Time { return now }
+
+	if got, resp := getCached(t, "v1/catalog/us/songs/1"); got != "1" || resp.Header.Get(CacheHeader) != "" {
+		t.Errorf("first request = %v (cached %q), want 1 from the server", got, resp.Header.Get(CacheHeader))
+	}
+	if got, resp := getCached(t, "v1/catalog/us/songs/1"); got != "1" || resp.Header.Get(CacheHeader) != "1" {
+		t.Errorf("second request = %v (cached %q), want 1 from the cache", got, resp.Header.Get(CacheHeader))
+	}
+
+	// Different language is a different cache entry.
+	if got, _ := getCached(t, "v1/catalog/us/songs/1?l=fr"); got != "2" {
+		t.Errorf("request with language = %v, want 2", got)
+	}
+
+	// Once stale, the response is fetched again.
+	now = now.Add(61 * time.Second)
+	if got, _ := getCached(t, "v1/catalog/us/songs/1"); got != "3" {
+		t.Errorf("stale request = %v, want 3", got)
+	}
+}
+
+func TestCacheTransport_etag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/catalog/us/albums/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	setupCache("")
+
+	getCached(t, "v1/catalog/us/albums/1")
+	got, resp := getCached(t, "v1/catalog/us/albums/1")
+	if got != "a" || resp.StatusCode != http.StatusOK || resp.Header.Get(CacheHeader) != "1" {
+		t.Errorf("revalidated request = %v %v (cached %q), want 200 a from the cache", resp.StatusCode, got, resp.Header.Get(CacheHeader))
+	}
+	if calls != 2 {
+		t.Errorf("Server called %d times, want 2", calls)
+	}
+}
+
+func TestCacheTransport_noHeader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/albums/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	ct := &CacheTransport{Cache: NewMemoryCache(0)}
+	req, _ := http.NewRequest("GET", server.URL+"/v1/catalog/us/albums/1", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	key, _ := cacheKey(req)
+	ct.Cache.Set(key, []byte(`{"statusCode":200,"body":"eyJBIjoiYSJ9","storedAt":"2000-01-01T00:00:00Z"}`))
+
+	resp, err := ct.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified || resp.Header.Get(CacheHeader) != "" {
+		t.Errorf("RoundTrip = %v (cached %q), want 304 from the server", resp.StatusCode, resp.Header.Get(CacheHeader))
+	}
+	if _, ok := ct.Cache.Get(key); ok {
+		t.Errorf("Cache has the entry without headers, want it deleted")
+	}
+}
+
+func TestCacheTransport_noStore(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/catalog/us/songs/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "no-store, max-age=60")
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	setupCache("")
+	getCached(t, "v1/catalog/us/songs/1")
+	getCached(t, "v1/catalog/us/songs/1")
+	if calls != 2 {
+		t.Errorf("Server called %d times, want 2", calls)
+	}
+}
+
+func TestCacheTransport_musicUserToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprintf(w, `{"A":"%s"}`, r.Header.Get("Music-User-Token"))
+	})
+
+	ct := setupCache("USER1")
+	if got, _ := getCached(t, "v1/me/library/songs"); got != "USER1" {
+		t.Errorf("first user request = %v, want USER1", got)
+	}
+	if got, resp := getCached(t, "v1/me/library/songs"); got != "USER1" || resp.Header.Get(CacheHeader) != "1" {
+		t.Errorf("first user request = %v (cached %q), want USER1 from the cache", got, resp.Header.Get(CacheHeader))
+	}
+
+	// Another user sharing the same cache gets its own response.
+	tp := &Transport{Token: "TOKEN", MusicUserToken: "USER2", Transport: ct}
+	baseURL := client.BaseURL
+	client = NewClient(tp.Client())
+	client.BaseURL = baseURL
+	if got, _ := getCached(t, "v1/me/library/songs"); got != "USER2" {
+		t.Errorf("second user request = %v, want USER2", got)
+	}
+}
+
+func Test_cacheKey(t *testing.T) {
+	testCases := []struct {
+		method, url, userToken string
+		cached                 bool
+	}{
+		{"GET", "https://api.music.apple.com/v1/catalog/us/songs/1", "", true},
+		{"POST", "https://api.music.apple.com/v1/me/library/playlists", "USER", false},
+		{"GET", "https://api.music.apple.com/v1/me/library/songs", "", false},
+		{"GET", "https://api.music.apple.com/v1/me/library/songs", "USER", true},
+		{"GET", "https://api.music.apple.com/v1/me", "", false},
+	}
+	for _, tc := range testCases {
+		req, _ := http.NewRequest(tc.method, tc.url, nil)
+		if tc.userToken != "" {
+			req.Header.Set("Music-User-Token", tc.userToken)
+		}
+		if _, cached := cacheKey(req); cached != tc.cached {
+			t.Errorf("cacheKey(%v %v, %q) cached = %v, want %v", tc.method, tc.url, tc.userToken, cached, tc.cached)
+		}
+	}
+}
+
+func Test_cachedResponse_fresh(t *testing.T) {
+	storedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		header http.Header
+		age    time.Duration
+		want   bool
+	}{
+		{http.Header{"Cache-Control": {"max-age=60"}}, 59 * time.Second, true},
+		{http.Header{"Cache-Control": {"max-age=60"}}, 60 * time.Second, false},
+		{http.Header{"Cache-Control": {"max-age=60"}, "Age": {"30"}}, 31 * time.Second, false},
+		{http.Header{"Cache-Control": {"no-cache, max-age=60"}}, 0, false},
+		{http.Header{"Date": {"Mon, 01 Jan 2018 00:00:00 GMT"}, "Expires": {"Mon, 01 Jan 2018 01:00:00 GMT"}}, 59 * time.Minute, true},
+		{http.Header{"Date": {"Mon, 01 Jan 2018 00:00:00 GMT"}, "Expires": {"Mon, 01 Jan 2018 01:00:00 GMT"}}, 61 * time.Minute, false},
+		{http.Header{"ETag": {`"v1"`}}, 0, false},
+	}
+	for k, tc := range testCases {
+		c := &cachedResponse{Header: tc.header, StoredAt: storedAt}
+		if got := c.fresh(storedAt.Add(tc.age)); got != tc.want {
+			t.Errorf("case=%d fresh = %v, want %v", k, got, tc.want)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package applemusic
 
+import "context"
+
 // CatalogService handles communication with the catalog related methods of the Apple Music API.
 type CatalogService service
 
@@ -7,4 +9,48 @@ type CatalogService service
 type Tracks struct {
 	Data []Resource `json:"data"`
 	Href string     `json:"href,omitempty"`
+	Next string     `json:"next,omitempty"`
+}
+
+// Songs returns the songs in the list of tracks.
+func (t Tracks) Songs() ([]Song, error) {
+	var songs []Song
+	err := parseResources(t.Data, "songs", &songs)
+	return songs, err
+}
+
+// MusicVideos returns the music videos in the list of tracks.
+func (t Tracks) MusicVideos() ([]MusicVideo, error) {
+	var musicVideos []MusicVideo
+	err := parseResources(t.Data, "music-videos", &musicVideos)
+	return musicVideos, err
+}
+
+// LibrarySongs returns the library songs in the list of tracks, such as the tracks of a library playlist.
+func (t Tracks) LibrarySongs() ([]LibrarySong, error) {
+	var librarySongs []LibrarySong
+	err := parseResources(t.Data, "library-songs", &librarySongs)
+	return librarySongs, err
+}
+
+// LibraryMusicVideos returns the library music videos in the list of tracks, such as the tracks of a library playlist.
+func (t Tracks) LibraryMusicVideos() ([]LibraryMusicVideo, error) {
+	var libraryMusicVideos []LibraryMusicVideo
+	err := parseResources(t.Data, "library-music-videos", &libraryMusicVideos)
+	return libraryMusicVideos, err
+}
+
+func (s *CatalogService) getTracks(ctx context.Context, u string) (*Tracks, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tracks := &Tracks{}
+	resp, err := s.client.Do(ctx, req, tracks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tracks, resp, nil
 }
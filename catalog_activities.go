@@ -63,10 +63,24 @@ func (s *CatalogService) GetActivity(ctx context.Context, storefront, id string,
 // GetActivitiesByIds fetches one or more activities using their identifiers.
 func (s *CatalogService) GetActivitiesByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Activities, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/activities", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	activities := &Activities{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, activities, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return activities, resp, nil
+}
+
+// GetActivityPlaylists fetches the playlists of an activity using its identifier.
+func (s *CatalogService) GetActivityPlaylists(ctx context.Context, storefront, id string, opt *PageOptions) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/activities/%s/playlists", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getActivities(ctx, u)
+	return s.getPlaylists(ctx, u)
 }
@@ -17,10 +17,17 @@ type AlbumAttributes struct {
 	IsSingle       bool            `json:"isSingle"`
 	Name           string          `json:"name"`
 	RecordLabel    string          `json:"recordLabel"`
-	ReleaseDate    string          `json:"releaseDate"`
+	ReleaseDate    Date            `json:"releaseDate"`
 	PlayParams     *PlayParameters `json:"playParams,omitempty"`
 	TrackCount     int64           `json:"trackCount"`
+	UPC            string          `json:"upc,omitempty"`
 	URL            string          `json:"url"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	ArtistURL        string           `json:"artistUrl,omitempty"`
+	AudioVariants    []string         `json:"audioVariants,omitempty"`
+	EditorialArtwork EditorialArtwork `json:"editorialArtwork,omitempty"`
+	EditorialVideo   EditorialVideo   `json:"editorialVideo,omitempty"`
 }
 
 // AlbumRelationships represents a to-one or to-many relationship from one resource object to others.
@@ -75,10 +82,75 @@ func (s *CatalogService) GetAlbum(ctx context.Context, storefront, id string, op
 // GetAlbumsByIds fetches one or more albums using their identifiers.
 func (s *CatalogService) GetAlbumsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Albums, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/albums", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	albums := &Albums{}
+	resp, err := s.client.getInBatches(ctx, ids, maxAlbumIdsPerRequest, albums, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return albums, resp, nil
+}
+
+// GetAlbumsByUpcs fetches one or more albums using their UPC identifiers.
+func (s *CatalogService) GetAlbumsByUpcs(ctx context.Context, storefront string, upcs []string, opt *Options) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/albums", storefront)
+	albums := &Albums{}
+	resp, err := s.client.getInBatches(ctx, upcs, maxUpcsPerRequest, albums, func(batch []string) (string, error) {
+		return addOptions(u, makeUpcsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return albums, resp, nil
+}
+
+// GetAlbumsByEquivalents fetches the albums of the storefront equivalent to the albums of another storefront,
+// using the identifiers of the latter. The equivalent albums may have other identifiers.
+func (s *CatalogService) GetAlbumsByEquivalents(ctx context.Context, storefront string, ids []string, opt *Options) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/albums", storefront)
+	albums := &Albums{}
+	resp, err := s.client.getInBatches(ctx, ids, maxEquivalentsPerRequest, albums, func(batch []string) (string, error) {
+		return addOptions(u, makeEquivalentsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return albums, resp, nil
+}
+
+// GetAlbumArtists fetches the artists of an album using its identifier.
+func (s *CatalogService) GetAlbumArtists(ctx context.Context, storefront, id string, opt *PageOptions) (*Artists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/albums/%s/artists", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getAlbums(ctx, u)
+	return s.getArtists(ctx, u)
+}
+
+// GetAlbumGenres fetches the genres of an album using its identifier.
+func (s *CatalogService) GetAlbumGenres(ctx context.Context, storefront, id string, opt *PageOptions) (*Genres, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/albums/%s/genres", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getGenres(ctx, u)
+}
+
+// GetAlbumTracks fetches the songs and music videos of an album using its identifier.
+func (s *CatalogService) GetAlbumTracks(ctx context.Context, storefront, id string, opt *PageOptions) (*Tracks, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/albums/%s/tracks", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getTracks(ctx, u)
 }
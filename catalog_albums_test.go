@@ -46,6 +46,52 @@ func TestCatalogService_GetAlbumsByIds(t *testing.T) {
 	}
 }
 
+func TestCatalogService_GetAlbumsByUpcs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter[upc]": "886443673441,000000000000",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(albumsJSON)
+	})
+
+	upcs := []string{"886443673441", "000000000000"}
+	got, _, err := client.Catalog.GetAlbumsByUpcs(context.Background(), "us", upcs, nil)
+	if err != nil {
+		t.Errorf("Catalog.GetAlbumsByUpcs returned error: %v", err)
+	}
+	if want := albums; !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.GetAlbumsByUpcs = %+v, want %+v", got, want)
+	}
+	if missing, want := MissingUpcs(upcs, got), []string{"000000000000"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("MissingUpcs = %v, want %v", missing, want)
+	}
+}
+
+func TestCatalogService_GetAlbumsByEquivalents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/jp/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter[equivalents]": "310730204",
+		})
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, _, err := client.Catalog.GetAlbumsByEquivalents(context.Background(), "jp", []string{"310730204"}, nil)
+	if err != nil {
+		t.Errorf("Catalog.GetAlbumsByEquivalents returned error: %v", err)
+	}
+}
+
 var albumsJSON = []byte(`{
   "data": [
     {
@@ -84,6 +130,7 @@ var albumsJSON = []byte(`{
         "recordLabel": "Columbia",
         "releaseDate": "1975-08-25",
         "trackCount": 8,
+        "upc": "886443673441",
         "url": "https://itunes.apple.com/us/album/born-to-run/id310730204"
       },
       "href": "/v1/catalog/us/albums/310730204",
@@ -186,6 +233,7 @@ var albums = &Albums{
 				RecordLabel: "Columbia",
 				ReleaseDate: "1975-08-25",
 				TrackCount:  8,
+				UPC:         "886443673441",
 				URL:         "https://itunes.apple.com/us/album/born-to-run/id310730204",
 			},
 			Href: "/v1/catalog/us/albums/310730204",
@@ -251,3 +299,46 @@ var albums = &Albums{
 		},
 	},
 }
+
+func TestCatalogService_GetAlbumTracks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/albums/1440881323/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit":  "2",
+			"offset": "8",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+    "data": [
+        {"id": "1440881324", "type": "songs", "attributes": {"name": "Red Light"}},
+        {"id": "1440881325", "type": "songs", "attributes": {"name": "\"40\""}}
+    ],
+    "href": "/v1/catalog/us/albums/1440881323/tracks?limit=2&offset=8",
+    "next": "/v1/catalog/us/albums/1440881323/tracks?offset=10"
+}`))
+	})
+
+	got, _, err := client.Catalog.GetAlbumTracks(context.Background(), "us", "1440881323", &PageOptions{Limit: 2, Offset: 8})
+	if err != nil {
+		t.Fatalf("Catalog.GetAlbumTracks returned error: %v", err)
+	}
+	if want := "/v1/catalog/us/albums/1440881323/tracks?offset=10"; got.Next != want {
+		t.Errorf("Catalog.GetAlbumTracks next = %v, want %v", got.Next, want)
+	}
+
+	songs, err := got.Songs()
+	if err != nil {
+		t.Errorf("Tracks.Songs returned error: %v", err)
+	}
+	want := []Song{
+		{Id: "1440881324", Type: "songs", Attributes: SongAttributes{Name: "Red Light"}},
+		{Id: "1440881325", Type: "songs", Attributes: SongAttributes{Name: "\"40\""}},
+	}
+	if !reflect.DeepEqual(songs, want) {
+		t.Errorf("Tracks.Songs = %+v, want %+v", songs, want)
+	}
+}
@@ -41,10 +41,24 @@ func (s *CatalogService) GetAppleCurator(ctx context.Context, storefront, id str
 // GetAppleCuratorsByIds fetches one or more apple curators using their identifiers.
 func (s *CatalogService) GetAppleCuratorsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*AppleCurators, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/apple-curators", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	appleCurators := &AppleCurators{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, appleCurators, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return appleCurators, resp, nil
+}
+
+// GetAppleCuratorPlaylists fetches the playlists of an Apple curator using its identifier.
+func (s *CatalogService) GetAppleCuratorPlaylists(ctx context.Context, storefront, id string, opt *PageOptions) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/apple-curators/%s/playlists", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getAppleCurators(ctx, u)
+	return s.getPlaylists(ctx, u)
 }
@@ -11,6 +11,16 @@ type ArtistAttributes struct {
 	EditorialNotes *EditorialNotes `json:"editorialNotes,omitempty"`
 	Name           string          `json:"name"`
 	URL            string          `json:"url"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	ArtistBio           string           `json:"artistBio,omitempty"`
+	Artwork             *Artwork         `json:"artwork,omitempty"`
+	BornOrFormed        string           `json:"bornOrFormed,omitempty"`
+	EditorialArtwork    EditorialArtwork `json:"editorialArtwork,omitempty"`
+	EditorialVideo      EditorialVideo   `json:"editorialVideo,omitempty"`
+	IsGroup             bool             `json:"isGroup,omitempty"`
+	Origin              string           `json:"origin,omitempty"`
+	PlainEditorialNotes *EditorialNotes  `json:"plainEditorialNotes,omitempty"`
 }
 
 // ArtistRelationships represents a to-one or to-many relationship from one resource object to others.
@@ -66,10 +76,123 @@ func (s *CatalogService) GetArtist(ctx context.Context, storefront, id string, o
 // GetArtistsByIds fetches one or more artists using their identifiers.
 func (s *CatalogService) GetArtistsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Artists, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/artists", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	artists := &Artists{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, artists, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return artists, resp, nil
+}
+
+// GetArtistAlbums fetches the albums of an artist using its identifier.
+func (s *CatalogService) GetArtistAlbums(ctx context.Context, storefront, id string, opt *PageOptions) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/albums", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getAlbums(ctx, u)
+}
+
+// GetArtistGenres fetches the genres of an artist using its identifier.
+func (s *CatalogService) GetArtistGenres(ctx context.Context, storefront, id string, opt *PageOptions) (*Genres, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/genres", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getGenres(ctx, u)
+}
+
+// GetArtistMusicVideos fetches the music videos of an artist using its identifier.
+func (s *CatalogService) GetArtistMusicVideos(ctx context.Context, storefront, id string, opt *PageOptions) (*MusicVideos, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/music-videos", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getMusicVideos(ctx, u)
+}
+
+// GetArtistPlaylists fetches the playlists of an artist using its identifier.
+func (s *CatalogService) GetArtistPlaylists(ctx context.Context, storefront, id string, opt *PageOptions) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/playlists", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getPlaylists(ctx, u)
+}
+
+// GetArtistStation fetches the station of an artist using its identifier.
+func (s *CatalogService) GetArtistStation(ctx context.Context, storefront, id string, opt *Options) (*Stations, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/station", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getStations(ctx, u)
+}
+
+// GetArtistTopSongs fetches the top songs view of an artist using its identifier.
+func (s *CatalogService) GetArtistTopSongs(ctx context.Context, storefront, id string, opt *PageOptions) (*Songs, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/view/top-songs", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getSongs(ctx, u)
+}
+
+// GetArtistLatestRelease fetches the latest release view of an artist using its identifier.
+func (s *CatalogService) GetArtistLatestRelease(ctx context.Context, storefront, id string, opt *PageOptions) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/view/latest-release", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getAlbums(ctx, u)
+}
+
+// GetArtistFullAlbums fetches the full albums view of an artist using its identifier.
+func (s *CatalogService) GetArtistFullAlbums(ctx context.Context, storefront, id string, opt *PageOptions) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/view/full-albums", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getAlbums(ctx, u)
+}
+
+// GetArtistSimilarArtists fetches the similar artists view of an artist using its identifier.
+func (s *CatalogService) GetArtistSimilarArtists(ctx context.Context, storefront, id string, opt *PageOptions) (*Artists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/view/similar-artists", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	return s.getArtists(ctx, u)
 }
+
+// GetArtistFeaturedPlaylists fetches the featured playlists view of an artist using its identifier.
+func (s *CatalogService) GetArtistFeaturedPlaylists(ctx context.Context, storefront, id string, opt *PageOptions) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/artists/%s/view/featured-playlists", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getPlaylists(ctx, u)
+}
@@ -105,3 +105,164 @@ var artists = &Artists{
 		},
 	},
 }
+
+func TestCatalogService_GetArtistAlbums(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/artists/78500/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		if r.FormValue("offset") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"1440881323","type":"albums"}],"next":"/v1/catalog/us/artists/78500/albums?offset=1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"1443155637","type":"albums"}]}`))
+	})
+
+	got, _, err := client.Catalog.GetArtistAlbums(context.Background(), "us", "78500", nil)
+	if err != nil {
+		t.Fatalf("Catalog.GetArtistAlbums returned error: %v", err)
+	}
+	if _, err := client.CollectAll(context.Background(), got, nil); err != nil {
+		t.Fatalf("CollectAll returned error: %v", err)
+	}
+	want := &Albums{
+		Data: []Album{
+			{Id: "1440881323", Type: "albums"},
+			{Id: "1443155637", Type: "albums"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.GetArtistAlbums = %+v, want %+v", got, want)
+	}
+}
+
+func TestCatalogService_GetArtistViews(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/artists/78500/view/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/v1/catalog/us/artists/78500/view/top-songs":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1440881325","type":"songs"}]}`))
+		case "/v1/catalog/us/artists/78500/view/latest-release":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1577502911","type":"albums"}]}`))
+		case "/v1/catalog/us/artists/78500/view/similar-artists":
+			_, _ = w.Write([]byte(`{"data":[{"id":"3296287","type":"artists"}]}`))
+		case "/v1/catalog/us/artists/78500/view/featured-playlists":
+			_, _ = w.Write([]byte(`{"data":[{"id":"pl.a8ab3a5bd0b64cd3ad1e3fbe1ba3de55","type":"playlists"}]}`))
+		default:
+			t.Errorf("Request URL path = %v, want an artist view", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+
+	songs, _, err := client.Catalog.GetArtistTopSongs(ctx, "us", "78500", nil)
+	if err != nil {
+		t.Errorf("Catalog.GetArtistTopSongs returned error: %v", err)
+	}
+	if want := (&Songs{Data: []Song{{Id: "1440881325", Type: "songs"}}}); !reflect.DeepEqual(songs, want) {
+		t.Errorf("Catalog.GetArtistTopSongs = %+v, want %+v", songs, want)
+	}
+
+	albums, _, err := client.Catalog.GetArtistLatestRelease(ctx, "us", "78500", nil)
+	if err != nil {
+		t.Errorf("Catalog.GetArtistLatestRelease returned error: %v", err)
+	}
+	if want := (&Albums{Data: []Album{{Id: "1577502911", Type: "albums"}}}); !reflect.DeepEqual(albums, want) {
+		t.Errorf("Catalog.GetArtistLatestRelease = %+v, want %+v", albums, want)
+	}
+
+	artists, _, err := client.Catalog.GetArtistSimilarArtists(ctx, "us", "78500", nil)
+	if err != nil {
+		t.Errorf("Catalog.GetArtistSimilarArtists returned error: %v", err)
+	}
+	if want := (&Artists{Data: []Artist{{Id: "3296287", Type: "artists"}}}); !reflect.DeepEqual(artists, want) {
+		t.Errorf("Catalog.GetArtistSimilarArtists = %+v, want %+v", artists, want)
+	}
+
+	playlists, _, err := client.Catalog.GetArtistFeaturedPlaylists(ctx, "us", "78500", nil)
+	if err != nil {
+		t.Errorf("Catalog.GetArtistFeaturedPlaylists returned error: %v", err)
+	}
+	if want := (&Playlists{Data: []Playlist{{Id: "pl.a8ab3a5bd0b64cd3ad1e3fbe1ba3de55", Type: "playlists"}}}); !reflect.DeepEqual(playlists, want) {
+		t.Errorf("Catalog.GetArtistFeaturedPlaylists = %+v, want %+v", playlists, want)
+	}
+}
+
+func TestCatalogService_GetArtist_extend(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/artists/78500", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"extend":          "artistBio,editorialVideo",
+			"fields[artists]": "name,artistBio,editorialVideo",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+    "data": [
+        {
+            "attributes": {
+                "artistBio": "Ireland’s biggest band.",
+                "editorialVideo": {
+                    "motionDetailSquare": {
+                        "previewFrame": {
+                            "height": 3840,
+                            "url": "https://example.mzstatic.com/image/thumb/Video/{w}x{h}bb.jpg",
+                            "width": 3840
+                        },
+                        "video": "https://example.apple.com/motion.m3u8"
+                    }
+                },
+                "name": "U2"
+            },
+            "id": "78500",
+            "type": "artists"
+        }
+    ]
+}`))
+	})
+
+	opt := &Options{
+		Extend: []string{"artistBio", "editorialVideo"},
+		Fields: ResourceTypeValues{"artists": {"name", "artistBio", "editorialVideo"}},
+	}
+	got, _, err := client.Catalog.GetArtist(context.Background(), "us", "78500", opt)
+	if err != nil {
+		t.Errorf("Catalog.GetArtist returned error: %v", err)
+	}
+	want := &Artists{
+		Data: []Artist{
+			{
+				Id:   "78500",
+				Type: "artists",
+				Attributes: ArtistAttributes{
+					ArtistBio: "Ireland’s biggest band.",
+					EditorialVideo: EditorialVideo{
+						"motionDetailSquare": {
+							PreviewFrame: Artwork{
+								Height: 3840,
+								URL:    "https://example.mzstatic.com/image/thumb/Video/{w}x{h}bb.jpg",
+								Width:  3840,
+							},
+							Video: "https://example.apple.com/motion.m3u8",
+						},
+					},
+					Name: "U2",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.GetArtist = %+v, want %+v", got, want)
+	}
+}
@@ -49,8 +49,8 @@ type Charts struct {
 // ChartsOptions specifies the parameters to fetch charts.
 type ChartsOptions struct {
 	// A list of the types of charts to include in the results.
-	// The possible values are albums, songs, and music-videos.
-	Types string `url:"types"`
+	// The possible values are albums, songs, music-videos and playlists.
+	Types []ResourceType `url:"types,comma"`
 
 	// (Optional) The localization to use, specified by a language tag.
 	// The possible values are in the supportedLanguageTags array belonging to the Storefront object specified by storefront.
@@ -73,8 +73,25 @@ type ChartsOptions struct {
 	Offset int `url:"offset,omitempty"`
 }
 
+func (opt *ChartsOptions) validate() error {
+	if len(opt.Types) == 0 {
+		return &InvalidOptionError{Option: "ChartsOptions.Types", Reason: "must not be empty"}
+	}
+	if opt.Offset > 0 && opt.Chart == "" {
+		return &InvalidOptionError{Option: "ChartsOptions.Offset", Reason: "is only valid with chart specified"}
+	}
+	return validateLimit("ChartsOptions.Limit", opt.Limit, maxChartLimit)
+}
+
 // GetAllCharts fetches one or more charts.
 func (s *CatalogService) GetAllCharts(ctx context.Context, storefront string, opt *ChartsOptions) (*Charts, *Response, error) {
+	if opt == nil {
+		opt = &ChartsOptions{}
+	}
+	if err := opt.validate(); err != nil {
+		return nil, nil, err
+	}
+
 	u := fmt.Sprintf("v1/catalog/%s/charts", storefront)
 	u, err := addOptions(u, opt)
 	if err != nil {
@@ -3,6 +3,7 @@ package applemusic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"reflect"
 	"testing"
@@ -25,7 +26,7 @@ func TestCatalogService_GetAllCharts(t *testing.T) {
 	})
 
 	opt := &ChartsOptions{
-		Types: "songs,albums",
+		Types: []ResourceType{ResourceTypeSongs, ResourceTypeAlbums},
 		Genre: "20",
 		Limit: 1,
 	}
@@ -43,6 +44,26 @@ func TestCatalogService_GetAllCharts(t *testing.T) {
 	}
 }
 
+func TestCatalogService_GetAllCharts_invalidOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/charts", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Catalog.GetAllCharts sent a request with invalid options")
+	})
+
+	testCases := []*ChartsOptions{
+		nil,
+		{Types: []ResourceType{ResourceTypeSongs}, Limit: 51},
+		{Types: []ResourceType{ResourceTypeSongs}, Offset: 20},
+	}
+	for _, opt := range testCases {
+		if _, _, err := client.Catalog.GetAllCharts(context.Background(), "us", opt); !errors.Is(err, ErrBadParameter) {
+			t.Errorf("Catalog.GetAllCharts(%+v) returned error %v, want ErrBadParameter", opt, err)
+		}
+	}
+}
+
 var chartsJSON = []byte(`{
     "results": {
         "albums": [
@@ -63,10 +63,24 @@ func (s *CatalogService) GetCurator(ctx context.Context, storefront, id string,
 // GetCuratorsByIds fetches one or more curators using their identifiers.
 func (s *CatalogService) GetCuratorsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Curators, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/curators", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	curators := &Curators{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, curators, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return curators, resp, nil
+}
+
+// GetCuratorPlaylists fetches the playlists of a curator using its identifier.
+func (s *CatalogService) GetCuratorPlaylists(ctx context.Context, storefront, id string, opt *PageOptions) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/curators/%s/playlists", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getCurators(ctx, u)
+	return s.getPlaylists(ctx, u)
 }
@@ -54,12 +54,15 @@ func (s *CatalogService) GetGenre(ctx context.Context, storefront, id string, op
 // GetGenresByIds fetches one or more genres.
 func (s *CatalogService) GetGenresByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Genres, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/genres", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	genres := &Genres{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, genres, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	return s.getGenres(ctx, u)
+	return genres, resp, nil
 }
 
 // GetAllGenres fetches all genres for the current top charts.
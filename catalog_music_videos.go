@@ -12,14 +12,18 @@ type MusicVideoAttributes struct {
 	GenreNames       []string        `json:"genreNames"`
 	ISRC             string          `json:"isrc"`
 	ArtistName       string          `json:"artistName"`
-	ReleaseDate      string          `json:"releaseDate"`
+	ReleaseDate      Date            `json:"releaseDate"`
 	Artwork          Artwork         `json:"artwork"`
 	PlayParams       *PlayParameters `json:"playParams,omitempty"`
-	DurationInMillis int64           `json:"durationInMillis,omitempty"`
+	DurationInMillis Milliseconds    `json:"durationInMillis,omitempty"`
 	ContentRating    string          `json:"contentRating,omitempty"`
 	EditorialNotes   *EditorialNotes `json:"editorialNotes,omitempty"`
 	TrackNumber      int             `json:"trackNumber,omitempty"`
 	VideoSubType     string          `json:"videoSubType,omitempty"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	ArtistURL      string         `json:"artistUrl,omitempty"`
+	EditorialVideo EditorialVideo `json:"editorialVideo,omitempty"`
 }
 
 // MusicVideoRelationships represents a to-one or to-many relationship from one resource object to others.
@@ -74,10 +78,75 @@ func (s *CatalogService) GetMusicVideo(ctx context.Context, storefront, id strin
 // GetMusicVideosByIds fetches one or more music videos using their identifiers.
 func (s *CatalogService) GetMusicVideosByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*MusicVideos, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/music-videos", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	musicVideos := &MusicVideos{}
+	resp, err := s.client.getInBatches(ctx, ids, maxMusicVideoIdsPerRequest, musicVideos, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return musicVideos, resp, nil
+}
+
+// GetMusicVideosByIsrcs fetches one or more music videos using their ISRC identifiers.
+func (s *CatalogService) GetMusicVideosByIsrcs(ctx context.Context, storefront string, isrcs []string, opt *Options) (*MusicVideos, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/music-videos", storefront)
+	musicVideos := &MusicVideos{}
+	resp, err := s.client.getInBatches(ctx, isrcs, maxIsrcsPerRequest, musicVideos, func(batch []string) (string, error) {
+		return addOptions(u, makeIsrcsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return musicVideos, resp, nil
+}
+
+// GetMusicVideosByEquivalents fetches the music videos of the storefront equivalent to the music videos of another
+// storefront, using the identifiers of the latter. The equivalent music videos may have other identifiers.
+func (s *CatalogService) GetMusicVideosByEquivalents(ctx context.Context, storefront string, ids []string, opt *Options) (*MusicVideos, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/music-videos", storefront)
+	musicVideos := &MusicVideos{}
+	resp, err := s.client.getInBatches(ctx, ids, maxEquivalentsPerRequest, musicVideos, func(batch []string) (string, error) {
+		return addOptions(u, makeEquivalentsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return musicVideos, resp, nil
+}
+
+// GetMusicVideoAlbums fetches the albums of a music video using its identifier.
+func (s *CatalogService) GetMusicVideoAlbums(ctx context.Context, storefront, id string, opt *PageOptions) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/music-videos/%s/albums", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getMusicVideos(ctx, u)
+	return s.getAlbums(ctx, u)
+}
+
+// GetMusicVideoArtists fetches the artists of a music video using its identifier.
+func (s *CatalogService) GetMusicVideoArtists(ctx context.Context, storefront, id string, opt *PageOptions) (*Artists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/music-videos/%s/artists", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getArtists(ctx, u)
+}
+
+// GetMusicVideoGenres fetches the genres of a music video using its identifier.
+func (s *CatalogService) GetMusicVideoGenres(ctx context.Context, storefront, id string, opt *PageOptions) (*Genres, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/music-videos/%s/genres", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getGenres(ctx, u)
 }
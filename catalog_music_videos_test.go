@@ -46,6 +46,48 @@ func TestCatalogService_GetMusicVideosByIds(t *testing.T) {
 	}
 }
 
+func TestCatalogService_GetMusicVideosByIsrcs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/music-videos", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter[isrc]": "USUV71300701",
+		})
+
+		_, _ = w.Write(musicVideosJSON)
+	})
+
+	got, _, err := client.Catalog.GetMusicVideosByIsrcs(context.Background(), "us", []string{"USUV71300701"}, nil)
+	if err != nil {
+		t.Errorf("Catalog.GetMusicVideosByIsrcs returned error: %v", err)
+	}
+	if missing := MissingIsrcs([]string{"USUV71300701"}, got); len(missing) != 0 {
+		t.Errorf("Catalog.GetMusicVideosByIsrcs missing %v", missing)
+	}
+}
+
+func TestCatalogService_GetMusicVideosByEquivalents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/jp/music-videos", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter[equivalents]": "639032181",
+			"l":                   "en-US",
+		})
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, _, err := client.Catalog.GetMusicVideosByEquivalents(context.Background(), "jp", []string{"639032181"}, &Options{Language: "en-US"})
+	if err != nil {
+		t.Errorf("Catalog.GetMusicVideosByEquivalents returned error: %v", err)
+	}
+}
+
 var musicVideosJSON = []byte(`{
   "data": [
     {
@@ -27,11 +27,16 @@ type PlaylistAttributes struct {
 	Artwork          *Artwork        `json:"artwork,omitempty"`
 	CuratorName      string          `json:"curatorName,omitempty"`
 	Description      *EditorialNotes `json:"description,omitempty"`
-	LastModifiedDate string          `json:"lastModifiedDate"`
+	LastModifiedDate Date            `json:"lastModifiedDate"`
 	Name             string          `json:"name"`
 	PlaylistType     PlaylistType    `json:"playlistType"`
 	PlayParams       *PlayParameters `json:"playParams,omitempty"`
 	URL              string          `json:"url"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	EditorialArtwork EditorialArtwork `json:"editorialArtwork,omitempty"`
+	EditorialVideo   EditorialVideo   `json:"editorialVideo,omitempty"`
+	TrackTypes       []string         `json:"trackTypes,omitempty"`
 }
 
 // PlaylistRelationships represents a to-one or to-many relationship from one resource object to others.
@@ -85,10 +90,35 @@ func (s *CatalogService) GetPlaylist(ctx context.Context, storefront, id string,
 // GetPlaylistsByIds fetches one or more playlists using their identifiers.
 func (s *CatalogService) GetPlaylistsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Playlists, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/playlists", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	playlists := &Playlists{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, playlists, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return playlists, resp, nil
+}
+
+// GetPlaylistCurator fetches the curator of a playlist using its identifier.
+func (s *CatalogService) GetPlaylistCurator(ctx context.Context, storefront, id string, opt *Options) (*Curators, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/playlists/%s/curator", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getPlaylists(ctx, u)
+	return s.getCurators(ctx, u)
+}
+
+// GetPlaylistTracks fetches the songs and music videos of a playlist using its identifier.
+func (s *CatalogService) GetPlaylistTracks(ctx context.Context, storefront, id string, opt *PageOptions) (*Tracks, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/playlists/%s/tracks", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getTracks(ctx, u)
 }
@@ -220,3 +220,31 @@ var playlists = &Playlists{
 		},
 	},
 }
+
+func TestCatalogService_GetPlaylistCurator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/playlists/pl.acc464c750b94302b8806e5fcbe56e17/curator", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"l": "en-us",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"976439548","type":"apple-curators","attributes":{"name":"Apple Music Pop"}}]}`))
+	})
+
+	got, _, err := client.Catalog.GetPlaylistCurator(context.Background(), "us", "pl.acc464c750b94302b8806e5fcbe56e17", &Options{Language: "en-us"})
+	if err != nil {
+		t.Errorf("Catalog.GetPlaylistCurator returned error: %v", err)
+	}
+	want := &Curators{
+		Data: []Curator{
+			{Id: "976439548", Type: "apple-curators", Attributes: CuratorAttributes{Name: "Apple Music Pop"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.GetPlaylistCurator = %+v, want %+v", got, want)
+	}
+}
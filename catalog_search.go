@@ -26,8 +26,10 @@ type Search struct {
 
 // SearchOptions specifies the parameters to search the catalog.
 type SearchOptions struct {
-	// The entered text for the search with ‘+’ characters between each word,
-	// to replace spaces (for example term=james+br).
+	// The entered text for the search (for example james br).
+	// The words are sent with ‘+’ characters between them, so they need not be inserted.
+	// A ‘+’ between two letters or digits, as in james+brown, still separates words,
+	// and the other ‘+’ characters, such as in C++, are encoded as %2B.
 	Term string `url:"term"`
 
 	// (Optional) The localization to use, specified by a language tag.
@@ -43,13 +45,32 @@ type SearchOptions struct {
 	Offset int `url:"offset,omitempty"`
 
 	// (Optional) The list of the types of resources to include in the results.
-	Types string `url:"types,omitempty"`
+	Types []ResourceType `url:"types,comma,omitempty"`
+}
+
+func (opt *SearchOptions) validate() error {
+	if searchTerm(opt.Term) == "" {
+		return &InvalidOptionError{Option: "SearchOptions.Term", Reason: "must not be empty"}
+	}
+	if opt.Offset > 0 && len(opt.Types) == 0 {
+		return &InvalidOptionError{Option: "SearchOptions.Offset", Reason: "is only valid with types specified"}
+	}
+	return validateLimit("SearchOptions.Limit", opt.Limit, maxSearchLimit)
 }
 
 // Search searches the catalog using a query.
 func (s *CatalogService) Search(ctx context.Context, storefront string, opt *SearchOptions) (*Search, *Response, error) {
+	if opt == nil {
+		opt = &SearchOptions{}
+	}
+	if err := opt.validate(); err != nil {
+		return nil, nil, err
+	}
+	searchOpt := *opt
+	searchOpt.Term = searchTerm(opt.Term)
+
 	u := fmt.Sprintf("v1/catalog/%s/search", storefront)
-	u, err := addOptions(u, opt)
+	u, err := addOptions(u, searchOpt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,10 +91,10 @@ func (s *CatalogService) Search(ctx context.Context, storefront string, opt *Sea
 
 // SearchHintsOptions specifies the parameters to search hints.
 type SearchHintsOptions struct {
-	Term     string `url:"term"`
-	Language string `url:"l,omitempty"`
-	Limit    int    `url:"limit,omitempty"` // (Optional) The number of search terms to be returned. The default value is 10.
-	Types    string `url:"types,omitempty"`
+	Term     string         `url:"term"`
+	Language string         `url:"l,omitempty"`
+	Limit    int            `url:"limit,omitempty"` // (Optional) The number of search terms to be returned. The default value is 10.
+	Types    []ResourceType `url:"types,comma,omitempty"`
 }
 
 // SearchHintsResults represents a results, that contains terms array.
@@ -88,8 +109,17 @@ type SearchHints struct {
 
 // SearchHints fetches the search term results for a hint.
 func (s *CatalogService) SearchHints(ctx context.Context, storefront string, opt *SearchHintsOptions) (*SearchHints, *Response, error) {
+	if opt == nil {
+		opt = &SearchHintsOptions{}
+	}
+	if searchTerm(opt.Term) == "" {
+		return nil, nil, &InvalidOptionError{Option: "SearchHintsOptions.Term", Reason: "must not be empty"}
+	}
+	hintsOpt := *opt
+	hintsOpt.Term = searchTerm(opt.Term)
+
 	u := fmt.Sprintf("v1/catalog/%s/search/hints", storefront)
-	u, err := addOptions(u, opt)
+	u, err := addOptions(u, hintsOpt)
 	if err != nil {
 		return nil, nil, err
 	}
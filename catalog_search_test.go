@@ -2,6 +2,7 @@ package applemusic
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"reflect"
 	"testing"
@@ -14,7 +15,7 @@ func TestCatalogService_Search(t *testing.T) {
 	mux.HandleFunc("/v1/catalog/us/search", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
 		testFormValues(t, r, values{
-			"term":  "james+brown",
+			"term":  "james brown",
 			"limit": "1",
 			"types": "artists,albums",
 		})
@@ -24,9 +25,9 @@ func TestCatalogService_Search(t *testing.T) {
 	})
 
 	opt := &SearchOptions{
-		Term:  "james+brown",
+		Term:  "james brown",
 		Limit: 1,
-		Types: "artists,albums",
+		Types: []ResourceType{ResourceTypeArtists, ResourceTypeAlbums},
 	}
 
 	got, _, err := client.Catalog.Search(context.Background(), "us", opt)
@@ -45,7 +46,7 @@ func TestCatalogService_SearchHints(t *testing.T) {
 	mux.HandleFunc("/v1/catalog/us/search/hints", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
 		testFormValues(t, r, values{
-			"term":  "james+brown",
+			"term":  "james brown",
 			"limit": "1",
 			"types": "artists,albums",
 		})
@@ -70,9 +71,9 @@ func TestCatalogService_SearchHints(t *testing.T) {
 	})
 
 	opt := &SearchHintsOptions{
-		Term:  "james+brown",
+		Term:  " james  brown ",
 		Limit: 1,
-		Types: "artists,albums",
+		Types: []ResourceType{ResourceTypeArtists, ResourceTypeAlbums},
 	}
 
 	searchHints := &SearchHints{
@@ -234,3 +235,67 @@ var search = &Search{
 		},
 	},
 }
+
+func TestCatalogService_Search_term(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/search", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RawQuery, "term=james+brown"; got != want {
+			t.Errorf("Request query = %v, want %v", got, want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":{}}`))
+	})
+
+	for _, term := range []string{"james brown", "  james   brown ", "james+brown"} {
+		if _, _, err := client.Catalog.Search(context.Background(), "us", &SearchOptions{Term: term}); err != nil {
+			t.Errorf("Catalog.Search(%q) returned error: %v", term, err)
+		}
+	}
+}
+
+func TestCatalogService_Search_plus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/search", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RawQuery, "term=C%2B%2B+%2B44"; got != want {
+			t.Errorf("Request query = %v, want %v", got, want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":{}}`))
+	})
+
+	if _, _, err := client.Catalog.Search(context.Background(), "us", &SearchOptions{Term: "C++ +44"}); err != nil {
+		t.Errorf("Catalog.Search returned error: %v", err)
+	}
+}
+
+func TestCatalogService_Search_invalidOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/search", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Catalog.Search sent a request with invalid options")
+	})
+
+	testCases := []*SearchOptions{
+		nil,
+		{Term: " \t "},
+		{Term: "james brown", Limit: 26},
+		{Term: "james brown", Limit: -1},
+		{Term: "james brown", Offset: 5},
+	}
+	for _, opt := range testCases {
+		_, _, err := client.Catalog.Search(context.Background(), "us", opt)
+		if !errors.Is(err, ErrBadParameter) {
+			t.Errorf("Catalog.Search(%+v) returned error %v, want ErrBadParameter", opt, err)
+		}
+		if _, ok := err.(*InvalidOptionError); !ok {
+			t.Errorf("Catalog.Search(%+v) returned error %T, want *InvalidOptionError", opt, err)
+		}
+	}
+}
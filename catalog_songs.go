@@ -13,8 +13,8 @@ type SongAttributes struct {
 	DiscNumber       int             `json:"discNumber"`
 	GenreNames       []string        `json:"genreNames"`
 	ISRC             string          `json:"isrc"`
-	DurationInMillis int64           `json:"durationInMillis,omitempty"`
-	ReleaseDate      string          `json:"releaseDate"`
+	DurationInMillis Milliseconds    `json:"durationInMillis,omitempty"`
+	ReleaseDate      Date            `json:"releaseDate"`
 	Name             string          `json:"name"`
 	PlayParams       *PlayParameters `json:"playParams,omitempty"`
 	TrackNumber      int             `json:"trackNumber,omitempty"`
@@ -27,6 +27,11 @@ type SongAttributes struct {
 	WorkName         string          `json:"workName,omitempty"`
 	Previews         *[]Preview      `json:"previews,omitempty"`
 	AlbumName        string          `json:"albumName"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	ArtistURL         string             `json:"artistUrl,omitempty"`
+	AudioVariants     []string           `json:"audioVariants,omitempty"`
+	ExtendedAssetURLs *ExtendedAssetURLs `json:"extendedAssetUrls,omitempty"`
 }
 
 // SongRelationships represents a to-one or to-many relationship from one resource object to others.
@@ -82,21 +87,86 @@ func (s *CatalogService) GetSong(ctx context.Context, storefront, id string, opt
 // GetSongsByIds fetches one or more songs using their identifiers.
 func (s *CatalogService) GetSongsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Songs, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/songs", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	songs := &Songs{}
+	resp, err := s.client.getInBatches(ctx, ids, maxSongIdsPerRequest, songs, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	return s.getSongs(ctx, u)
+	return songs, resp, nil
 }
 
 // GetSongsByIsrcs fetches one or more songs using their ISRC identifiers.
 func (s *CatalogService) GetSongsByIsrcs(ctx context.Context, storefront string, isrcs []string, opt *Options) (*Songs, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/songs", storefront)
-	u, err := addOptions(u, makeIsrcsOptions(isrcs, opt))
+	songs := &Songs{}
+	resp, err := s.client.getInBatches(ctx, isrcs, maxIsrcsPerRequest, songs, func(batch []string) (string, error) {
+		return addOptions(u, makeIsrcsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return songs, resp, nil
+}
+
+// GetSongsByEquivalents fetches the songs of the storefront equivalent to the songs of another storefront,
+// using the identifiers of the latter. The equivalent songs may have other identifiers.
+func (s *CatalogService) GetSongsByEquivalents(ctx context.Context, storefront string, ids []string, opt *Options) (*Songs, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/songs", storefront)
+	songs := &Songs{}
+	resp, err := s.client.getInBatches(ctx, ids, maxEquivalentsPerRequest, songs, func(batch []string) (string, error) {
+		return addOptions(u, makeEquivalentsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return songs, resp, nil
+}
+
+// GetSongAlbums fetches the albums of a song using its identifier.
+func (s *CatalogService) GetSongAlbums(ctx context.Context, storefront, id string, opt *PageOptions) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/songs/%s/albums", storefront, id)
+	u, err := addOptions(u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.getSongs(ctx, u)
+	return s.getAlbums(ctx, u)
+}
+
+// GetSongArtists fetches the artists of a song using its identifier.
+func (s *CatalogService) GetSongArtists(ctx context.Context, storefront, id string, opt *PageOptions) (*Artists, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/songs/%s/artists", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getArtists(ctx, u)
+}
+
+// GetSongGenres fetches the genres of a song using its identifier.
+func (s *CatalogService) GetSongGenres(ctx context.Context, storefront, id string, opt *PageOptions) (*Genres, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/songs/%s/genres", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getGenres(ctx, u)
+}
+
+// GetSongStation fetches the station of a song using its identifier.
+func (s *CatalogService) GetSongStation(ctx context.Context, storefront, id string, opt *Options) (*Stations, *Response, error) {
+	u := fmt.Sprintf("v1/catalog/%s/songs/%s/station", storefront, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.getStations(ctx, u)
 }
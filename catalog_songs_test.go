@@ -65,6 +65,25 @@ func TestCatalogService_GetSongsByIsrcs(t *testing.T) {
 	}
 }
 
+func TestCatalogService_GetSongsByEquivalents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/jp/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter[equivalents]": "203709340,201281527",
+		})
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, _, err := client.Catalog.GetSongsByEquivalents(context.Background(), "jp", []string{"203709340", "201281527"}, nil)
+	if err != nil {
+		t.Errorf("Catalog.GetSongsByEquivalents returned error: %v", err)
+	}
+}
+
 func TestCatalogService_GetSongsByIsrcsAndIdsWithOption(t *testing.T) {
 	setup()
 	defer teardown()
@@ -208,3 +227,28 @@ var songs = &Songs{
 		},
 	},
 }
+
+func TestCatalogService_GetSongStation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/catalog/us/songs/1440881325/station", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"ra.1440881325","type":"stations","attributes":{"name":"\"40\" Station"}}]}`))
+	})
+
+	got, _, err := client.Catalog.GetSongStation(context.Background(), "us", "1440881325", nil)
+	if err != nil {
+		t.Errorf("Catalog.GetSongStation returned error: %v", err)
+	}
+	want := &Stations{
+		Data: []Station{
+			{Id: "ra.1440881325", Type: "stations", Attributes: StationAttributes{Name: "\"40\" Station"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.GetSongStation = %+v, want %+v", got, want)
+	}
+}
@@ -13,8 +13,11 @@ type StationAttributes struct {
 	PlayParams       PlayParameters  `json:"playParams"` // Undocumented
 	EditorialNotes   *EditorialNotes `json:"editorialNotes,omitempty"`
 	IsLive           bool            `json:"isLive"`
-	DurationInMillis int64           `json:"durationInMillis,omitempty"`
+	DurationInMillis Milliseconds    `json:"durationInMillis,omitempty"`
 	EpisodeNumber    string          `json:"episodeNumber,omitempty"`
+
+	// Extended attributes, only returned when requested with the extend parameter.
+	EditorialVideo EditorialVideo `json:"editorialVideo,omitempty"`
 }
 
 // Station represents a station.
@@ -61,10 +64,13 @@ func (s *CatalogService) GetStation(ctx context.Context, storefront, id string,
 // GetStationsByIds fetches one or more stations using their identifiers.
 func (s *CatalogService) GetStationsByIds(ctx context.Context, storefront string, ids []string, opt *Options) (*Stations, *Response, error) {
 	u := fmt.Sprintf("v1/catalog/%s/stations", storefront)
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	stations := &Stations{}
+	resp, err := s.client.getInBatches(ctx, ids, maxStationIdsPerRequest, stations, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	return s.getStations(ctx, u)
+	return stations, resp, nil
 }
@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/minchao/go-apple-music"
+)
+
+// catalogType fetches the catalog resources of a type by identifier.
+type catalogType struct {
+	get   func(ctx context.Context, s *applemusic.CatalogService, storefront, id string) (interface{}, interface{}, error)
+	byIds func(ctx context.Context, s *applemusic.CatalogService, storefront string, ids []string) (interface{}, interface{}, error)
+}
+
+var catalogTypes = map[string]catalogType{
+	"activities": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetActivity(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetActivitiesByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"albums": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetAlbum(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetAlbumsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"apple-curators": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetAppleCurator(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetAppleCuratorsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"artists": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetArtist(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetArtistsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"curators": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetCurator(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetCuratorsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"genres": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetGenre(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetGenresByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"music-videos": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetMusicVideo(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetMusicVideosByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"playlists": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetPlaylist(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetPlaylistsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"songs": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetSong(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetSongsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+	"stations": {
+		func(ctx context.Context, s *applemusic.CatalogService, sf, id string) (interface{}, interface{}, error) {
+			v, _, err := s.GetStation(ctx, sf, id, nil)
+			return v, data(v), err
+		},
+		func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+			v, _, err := s.GetStationsByIds(ctx, sf, ids, nil)
+			return v, data(v), err
+		},
+	},
+}
+
+// equivalentTypes fetch the catalog resources of a type equivalent to the resources of another storefront.
+var equivalentTypes = map[string]func(ctx context.Context, s *applemusic.CatalogService, storefront string, ids []string) (interface{}, interface{}, error){
+	"albums": func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+		v, _, err := s.GetAlbumsByEquivalents(ctx, sf, ids, nil)
+		return v, data(v), err
+	},
+	"music-videos": func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+		v, _, err := s.GetMusicVideosByEquivalents(ctx, sf, ids, nil)
+		return v, data(v), err
+	},
+	"songs": func(ctx context.Context, s *applemusic.CatalogService, sf string, ids []string) (interface{}, interface{}, error) {
+		v, _, err := s.GetSongsByEquivalents(ctx, sf, ids, nil)
+		return v, data(v), err
+	},
+}
+
+func runStorefronts(c *cli, args []string) error {
+	fs := c.flagSet()
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var (
+		storefronts *applemusic.Storefronts
+		err         error
+	)
+	if fs.NArg() == 0 {
+		storefronts, _, err = c.client.Storefront.GetAll(c.ctx, nil)
+	} else {
+		storefronts, _, err = c.client.Storefront.GetByIds(c.ctx, fs.Args(), nil)
+	}
+	if err != nil {
+		return err
+	}
+	return c.out.print(storefronts, storefronts.Data)
+}
+
+func runCatalog(c *cli, args []string) error {
+	fs := c.flagSet()
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	args = fs.Args()
+	if len(args) < 2 {
+		return errUsage
+	}
+
+	var (
+		v, items interface{}
+		err      error
+	)
+	switch args[0] {
+	case "get", "by-ids":
+		if len(args) < 3 || args[0] == "get" && len(args) != 3 {
+			return errUsage
+		}
+		typ, ok := catalogTypes[args[1]]
+		if !ok {
+			return fmt.Errorf("unknown catalog type %q, must be one of %s", args[1], typeNames(catalogTypes))
+		}
+		if args[0] == "get" {
+			v, items, err = typ.get(c.ctx, c.client.Catalog, c.storefront, args[2])
+		} else {
+			v, items, err = typ.byIds(c.ctx, c.client.Catalog, c.storefront, ids(args[2:]))
+		}
+	case "by-isrc":
+		// The ISRCs are of songs, unless the type of the resources is given first.
+		isrcs := args[1:]
+		if args[1] == "songs" || args[1] == "music-videos" {
+			isrcs = args[2:]
+		}
+		if len(isrcs) == 0 {
+			return errUsage
+		}
+		if args[1] == "music-videos" {
+			var musicVideos *applemusic.MusicVideos
+			musicVideos, _, err = c.client.Catalog.GetMusicVideosByIsrcs(c.ctx, c.storefront, ids(isrcs), nil)
+			v, items = musicVideos, data(musicVideos)
+		} else {
+			var songs *applemusic.Songs
+			songs, _, err = c.client.Catalog.GetSongsByIsrcs(c.ctx, c.storefront, ids(isrcs), nil)
+			v, items = songs, data(songs)
+		}
+	case "by-upc":
+		var albums *applemusic.Albums
+		albums, _, err = c.client.Catalog.GetAlbumsByUpcs(c.ctx, c.storefront, ids(args[1:]), nil)
+		v, items = albums, data(albums)
+	case "equivalents":
+		if len(args) < 3 {
+			return errUsage
+		}
+		equivalents, ok := equivalentTypes[args[1]]
+		if !ok {
+			return fmt.Errorf("unknown equivalent type %q, must be one of %s", args[1], typeNames(equivalentTypes))
+		}
+		v, items, err = equivalents(c.ctx, c.client.Catalog, c.storefront, ids(args[2:]))
+	default:
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+	return c.out.print(v, items)
+}
+
+func runSearch(c *cli, args []string) error {
+	fs := c.flagSet()
+	types := fs.String("types", "", "Comma-separated list of the types of resources to search, such as songs,albums")
+	limit := fs.Int("limit", 0, "Number of resources of each type to return, up to 25")
+	offset := fs.Int("offset", 0, "Offset of the resources of each type to return")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	search, _, err := c.client.Catalog.Search(c.ctx, c.storefront, &applemusic.SearchOptions{
+		Term:   strings.Join(fs.Args(), " "),
+		Types:  resourceTypes(*types),
+		Limit:  *limit,
+		Offset: *offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	r := search.Results
+	return c.out.print(search,
+		data(r.Activities), data(r.Albums), data(r.AppleCurators), data(r.Artists), data(r.Curators),
+		data(r.MusicVideos), data(r.Playlists), data(r.Stations), data(r.Songs))
+}
+
+func runHints(c *cli, args []string) error {
+	fs := c.flagSet()
+	types := fs.String("types", "", "Comma-separated list of the types of resources to hint, such as songs,albums")
+	limit := fs.Int("limit", 0, "Number of search terms to return")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	hints, _, err := c.client.Catalog.SearchHints(c.ctx, c.storefront, &applemusic.SearchHintsOptions{
+		Term:  strings.Join(fs.Args(), " "),
+		Types: resourceTypes(*types),
+		Limit: *limit,
+	})
+	if err != nil {
+		return err
+	}
+	return c.out.print(hints, hints.Results.Terms)
+}
+
+func runCharts(c *cli, args []string) error {
+	fs := c.flagSet()
+	types := fs.String("types", "songs,albums,playlists", "Comma-separated list of the types of charts")
+	chart := fs.String("chart", "", "Chart to fetch, such as most-played")
+	genre := fs.String("genre", "", "Identifier of the genre of the charts")
+	limit := fs.Int("limit", 0, "Number of resources of each chart to return, up to 50")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+
+	charts, _, err := c.client.Catalog.GetAllCharts(c.ctx, c.storefront, &applemusic.ChartsOptions{
+		Types: resourceTypes(*types),
+		Chart: *chart,
+		Genre: *genre,
+		Limit: *limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	r := charts.Results
+	if r.Albums != nil {
+		for _, chart := range *r.Albums {
+			items = append(items, chart.Data)
+		}
+	}
+	if r.Songs != nil {
+		for _, chart := range *r.Songs {
+			items = append(items, chart.Data)
+		}
+	}
+	if r.MusicVideos != nil {
+		for _, chart := range *r.MusicVideos {
+			items = append(items, chart.Data)
+		}
+	}
+	if r.Playlists != nil {
+		for _, chart := range *r.Playlists {
+			items = append(items, chart.Data)
+		}
+	}
+	return c.out.print(charts, items...)
+}
+
+func runGenres(c *cli, args []string) error {
+	fs := c.flagSet()
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var (
+		genres *applemusic.Genres
+		err    error
+	)
+	if fs.NArg() == 0 {
+		genres, _, err = c.client.Catalog.GetAllGenres(c.ctx, c.storefront, nil)
+	} else {
+		genres, _, err = c.client.Catalog.GetGenresByIds(c.ctx, c.storefront, ids(fs.Args()), nil)
+	}
+	if err != nil {
+		return err
+	}
+	return c.out.print(genres, genres.Data)
+}
+
+func runAvailability(c *cli, args []string) error {
+	fs := c.flagSet()
+	typ := fs.String("type", "songs", "Type of the resources: songs or albums")
+	isrc := fs.Bool("isrc", false, "The arguments are ISRCs of songs rather than identifiers")
+	storefronts := fs.String("storefronts", "", "Comma-separated storefronts to check (default all)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	opt := &applemusic.AvailabilityOptions{Type: applemusic.ResourceType(*typ), Storefronts: ids([]string{*storefronts})}
+	if *isrc {
+		opt.Isrcs = ids(fs.Args())
+	} else {
+		opt.Ids = ids(fs.Args())
+	}
+	availability, err := c.client.GetAvailability(c.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if c.out.format != formatTable {
+		type row struct {
+			Key  string              `json:"key"`
+			Hits map[string][]string `json:"hits"`
+		}
+		rows := make([]row, 0, len(availability.Keys))
+		for _, key := range availability.Keys {
+			rows = append(rows, row{Key: key, Hits: availability.Hits[key]})
+		}
+		return c.out.print(availability, rows)
+	}
+
+	// The table tells the storefronts of each resource, with its local identifiers where they differ.
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tAVAILABLE\tSTOREFRONTS")
+	for _, key := range availability.Keys {
+		in := availability.AvailableIn(key)
+		var hits []string
+		for _, storefront := range in {
+			local := availability.LocalIds(key, storefront)
+			if len(local) == 1 && local[0] == key {
+				hits = append(hits, storefront)
+			} else {
+				hits = append(hits, fmt.Sprintf("%s(%s)", storefront, strings.Join(local, ",")))
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%d/%d\t%s\n", key, len(in), len(availability.Storefronts), strings.Join(hits, " "))
+	}
+	return tw.Flush()
+}
+
+// ids returns the identifiers of the arguments, which may be separated by commas as well.
+func ids(args []string) []string {
+	var ids []string
+	for _, arg := range args {
+		for _, id := range strings.Split(arg, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// typeNames returns the sorted names of the types of a map keyed by type name.
+func typeNames(types interface{}) string {
+	var names []string
+	for _, k := range reflect.ValueOf(types).MapKeys() {
+		names = append(names, k.String())
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
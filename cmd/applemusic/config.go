@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/minchao/go-apple-music"
+	"github.com/minchao/go-apple-music/token"
+)
+
+// tokenTTL is the TTL of the developer tokens generated from a private key, in seconds.
+const tokenTTL = 3600
+
+// config is the configuration of the tool, read from the config file, the environment and the flags.
+type config struct {
+	// The developer token, or the MusicKit key identifier, Team ID and private key file to generate it.
+	Token          string `json:"token,omitempty"`
+	KeyId          string `json:"keyId,omitempty"`
+	TeamId         string `json:"teamId,omitempty"`
+	PrivateKeyFile string `json:"privateKeyFile,omitempty"`
+
+	MusicUserToken string `json:"musicUserToken,omitempty"`
+	Storefront     string `json:"storefront,omitempty"`
+	Output         string `json:"output,omitempty"`
+	BaseURL        string `json:"baseURL,omitempty"`
+}
+
+// loadConfig reads the config file at path, then overrides it with the environment variables.
+// If path is empty, the file named by APPLE_MUSIC_CONFIG or the default file is read, if it exists.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := &config{}
+
+	explicit := true
+	if path == "" {
+		path = getenv("APPLE_MUSIC_CONFIG")
+	}
+	if path == "" {
+		explicit = false
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "applemusic", "config.json")
+		}
+	}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if len(bytes.TrimSpace(data)) == 0 {
+				break
+			}
+			if err := json.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+			}
+		case explicit || !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
+	cfg.merge(config{
+		Token:          getenv("APPLE_MUSIC_API_TOKEN"),
+		KeyId:          getenv("APPLE_MUSIC_KEY_ID"),
+		TeamId:         getenv("APPLE_MUSIC_TEAM_ID"),
+		PrivateKeyFile: getenv("APPLE_MUSIC_PRIVATE_KEY"),
+		MusicUserToken: getenv("APPLE_MUSIC_USER_TOKEN"),
+		Storefront:     getenv("APPLE_MUSIC_STOREFRONT"),
+	})
+	return cfg, nil
+}
+
+// merge overrides the configuration with the non-empty values of o.
+func (cfg *config) merge(o config) {
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&cfg.Token, o.Token)
+	set(&cfg.KeyId, o.KeyId)
+	set(&cfg.TeamId, o.TeamId)
+	set(&cfg.PrivateKeyFile, o.PrivateKeyFile)
+	set(&cfg.MusicUserToken, o.MusicUserToken)
+	set(&cfg.Storefront, o.Storefront)
+	set(&cfg.Output, o.Output)
+	set(&cfg.BaseURL, o.BaseURL)
+}
+
+// transport returns the transport that authenticates the requests with the configured tokens.
+// Without a developer token, the token is generated from the configured private key, which is then required.
+func (cfg *config) transport() (*applemusic.Transport, error) {
+	tp := &applemusic.Transport{
+		Token:          cfg.Token,
+		MusicUserToken: cfg.MusicUserToken,
+	}
+	if cfg.Token != "" {
+		return tp, nil
+	}
+	if cfg.PrivateKeyFile == "" {
+		return nil, fmt.Errorf("no developer token: set -t, APPLE_MUSIC_API_TOKEN, the private key or the config file")
+	}
+
+	secret, err := ioutil.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tp.TokenSource = token.NewSource(token.Generator{
+		KeyId:  cfg.KeyId,
+		TeamId: cfg.TeamId,
+		TTL:    tokenTTL,
+		Secret: secret,
+	}, 0)
+	return tp, nil
+}
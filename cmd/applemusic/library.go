@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/minchao/go-apple-music"
+)
+
+// libraryType fetches the first page of a collection of the user, such as the songs in the library.
+type libraryType func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error)
+
+var libraryTypes = map[string]libraryType{
+	"albums": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetAllLibraryAlbums(ctx, opt)
+		return v, err
+	},
+	"artists": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetAllLibraryArtists(ctx, opt)
+		return v, err
+	},
+	"music-videos": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetAllLibraryMusicVideos(ctx, opt)
+		return v, err
+	},
+	"playlists": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetAllLibraryPlaylists(ctx, opt)
+		return v, err
+	},
+	"recently-added": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetLibraryRecentlyAdded(ctx, opt)
+		return v, err
+	},
+	"songs": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetAllLibrarySongs(ctx, opt)
+		return v, err
+	},
+}
+
+var historyTypes = map[string]libraryType{
+	"heavy-rotation": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetHistoryHeavyRotation(ctx, opt)
+		return v, err
+	},
+	"recent": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetHistoryRecentlyPlayed(ctx, opt)
+		return v, err
+	},
+	"stations": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetHistoryRecentStations(ctx, opt)
+		return v, err
+	},
+	"tracks": func(ctx context.Context, s *applemusic.MeService, opt *applemusic.PageOptions) (interface{}, error) {
+		v, _, err := s.GetHistoryRecentlyPlayedTracks(ctx, opt)
+		return v, err
+	},
+}
+
+func runLibrary(c *cli, args []string) error {
+	fs := c.flagSet()
+	limit := fs.Int("limit", 0, "Number of resources to return per page")
+	all := fs.Bool("all", false, "Fetch all the pages")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	fetch, ok := libraryTypes[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown library type %q, must be one of %s", fs.Arg(0), typeNames(libraryTypes))
+	}
+	return c.printPages(fetch, *limit, *all)
+}
+
+func runHistory(c *cli, args []string) error {
+	fs := c.flagSet()
+	limit := fs.Int("limit", 0, "Number of resources to return per page")
+	all := fs.Bool("all", false, "Fetch all the pages")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errUsage
+	}
+
+	name := "recent"
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+	}
+	fetch, ok := historyTypes[name]
+	if !ok {
+		return fmt.Errorf("unknown history %q, must be one of %s", name, typeNames(historyTypes))
+	}
+	return c.printPages(fetch, *limit, *all)
+}
+
+// printPages prints the first page fetched by fetch, or all the pages.
+func (c *cli) printPages(fetch libraryType, limit int, all bool) error {
+	var opt *applemusic.PageOptions
+	if limit > 0 {
+		opt = &applemusic.PageOptions{Limit: limit}
+	}
+	page, err := fetch(c.ctx, c.client.Me, opt)
+	if err != nil {
+		return err
+	}
+	if all {
+		if _, err := c.client.CollectAll(c.ctx, page, nil); err != nil {
+			return err
+		}
+	}
+	return c.out.print(page, data(page))
+}
+
+func runExport(c *cli, args []string) error {
+	fs := c.flagSet()
+	format := fs.String("format", "json", "Format of the export: json, csv, m3u8 or xspf")
+	playlist := fs.String("playlist", "", "Identifier of the library playlist to export instead of the whole library")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+
+	return c.client.Me.ExportLibrary(c.ctx, c.stdout, &applemusic.ExportOptions{
+		Format:     applemusic.ExportFormat(*format),
+		PlaylistId: *playlist,
+	})
+}
+
+func runImport(c *cli, args []string) error {
+	fs := c.flagSet()
+	format := fs.String("format", "", "Format of the file: m3u8, csv or xspf (default from the file extension)")
+	name := fs.String("name", "", "Name of the playlist (default the title of the file)")
+	description := fs.String("description", "", "Description of the playlist")
+	dryRun := fs.Bool("dry-run", false, "Match the entries without creating the playlist")
+	ambiguous := fs.Bool("include-ambiguous", false, "Add the best candidates of the ambiguous entries")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(fs.Arg(0))) {
+		case ".m3u", ".m3u8":
+			*format = "m3u8"
+		default:
+			*format = strings.ToLower(strings.TrimPrefix(filepath.Ext(fs.Arg(0)), "."))
+		}
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := c.client.ImportPlaylist(c.ctx, c.storefront, f, &applemusic.ImportOptions{
+		Format:           applemusic.ExportFormat(*format),
+		Name:             *name,
+		Description:      *description,
+		DryRun:           *dryRun,
+		IncludeAmbiguous: *ambiguous,
+	})
+	if err != nil {
+		return err
+	}
+	if c.out.format != formatTable {
+		return c.out.print(report, report.Results)
+	}
+
+	// The table tells the song matched by each entry of the file.
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tENTRY\tSONG\tSCORE")
+	for _, result := range report.Results {
+		entry := result.Entry.Title
+		if entry == "" {
+			entry = result.Entry.Location
+		} else if result.Entry.ArtistName != "" {
+			entry = result.Entry.ArtistName + " - " + entry
+		}
+		song := ""
+		if result.Song != nil {
+			song = fmt.Sprintf("%s (%s - %s)", result.Song.Id, result.Song.Attributes.ArtistName, result.Song.Attributes.Name)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\n", result.Status, entry, song, result.Score)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if report.Playlist != nil {
+		fmt.Fprintf(c.stdout, "Created playlist %s %q: %d matched, %d ambiguous, %d unmatched\n", report.Playlist.Id,
+			report.Name, len(report.Matched()), len(report.Ambiguous()), len(report.Unmatched()))
+	}
+	return nil
+}
+
+func runPlaylist(c *cli, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errUsage
+	}
+
+	fs := c.flagSet()
+	name := fs.String("name", "", "Name of the playlist (required)")
+	description := fs.String("description", "", "Description of the playlist")
+	if err := parse(fs, args[1:]); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errUsage
+	}
+
+	body := applemusic.CreateLibraryPlaylist{
+		Attributes: applemusic.CreateLibraryPlaylistAttributes{Name: *name, Description: *description},
+	}
+	if tracks := playlistTracks(ids(fs.Args())); len(tracks) > 0 {
+		body.Relationships = &applemusic.CreateLibraryPlaylistRelationships{
+			Tracks: applemusic.CreateLibraryPlaylistTrackData{Data: tracks},
+		}
+	}
+
+	playlists, _, err := c.client.Me.CreateLibraryPlaylist(c.ctx, body, nil)
+	if err != nil {
+		return err
+	}
+	return c.out.print(playlists, playlists.Data)
+}
+
+// playlistTracks returns the tracks of the song identifiers, the library songs having identifiers prefixed with i.
+func playlistTracks(ids []string) []applemusic.CreateLibraryPlaylistTrack {
+	var tracks []applemusic.CreateLibraryPlaylistTrack
+	for _, id := range ids {
+		typ := "songs"
+		if strings.HasPrefix(id, "i.") {
+			typ = "library-songs"
+		}
+		tracks = append(tracks, applemusic.CreateLibraryPlaylistTrack{Id: id, Type: typ})
+	}
+	return tracks
+}
@@ -0,0 +1,216 @@
+// Command applemusic is a command-line client of the Apple Music API.
+//
+// Usage:
+//
+//	applemusic [options] <command> [arguments]
+//
+// The credentials and defaults are read from the flags, the environment variables
+// APPLE_MUSIC_API_TOKEN, APPLE_MUSIC_KEY_ID, APPLE_MUSIC_TEAM_ID, APPLE_MUSIC_PRIVATE_KEY,
+// APPLE_MUSIC_USER_TOKEN and APPLE_MUSIC_STOREFRONT, and a JSON config file, in that order of precedence. Run applemusic -h for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/minchao/go-apple-music"
+)
+
+var (
+	// errUsage is returned by the commands invoked with invalid arguments, to print their usage.
+	errUsage = errors.New("invalid usage")
+
+	// errFlags is returned by the commands invoked with invalid flags, whose errors are already printed.
+	errFlags = errors.New("invalid flags")
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	usage string // The arguments of the command.
+	help  string // The short description of the command.
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"storefronts":  {"[id...]", "List all the storefronts, or the storefronts with the identifiers", runStorefronts},
+	"availability": {"[options] <id...>", "Check in which storefronts songs or albums are available", runAvailability},
+	"catalog":      {"get|by-ids|by-isrc|by-upc|equivalents ...", "Fetch catalog resources by identifier, ISRC, UPC or equivalent in another storefront", runCatalog},
+	"search":       {"[options] <term>", "Search the catalog", runSearch},
+	"hints":        {"[options] <term>", "Fetch the search hints of a term", runHints},
+	"charts":       {"[options]", "Fetch the charts", runCharts},
+	"export":       {"[options]", "Export the library of the user, or one of its playlists, to the standard output", runExport},
+	"import":       {"[options] <file>", "Import a playlist file to the library of the user, matching its entries with the catalog", runImport},
+	"genres":       {"[id...]", "List all the top-level genres, or the genres with the identifiers", runGenres},
+	"library":      {"[options] <type>", "List the resources in the library of the user", runLibrary},
+	"history":      {"[options] [recent|tracks|heavy-rotation|stations]", "Fetch the listening history of the user", runHistory},
+	"playlist":     {"create [options] [song id...]", "Create a playlist in the library of the user", runPlaylist},
+}
+
+// cli is the state shared by the commands.
+type cli struct {
+	name, usage string // The name and the usage of the running command.
+
+	ctx        context.Context
+	client     *applemusic.Client
+	storefront string
+	out        *printer
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run runs the tool with the arguments and returns the exit code:
+// 0 on success, 1 if the command failed and 2 on invalid usage.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("applemusic", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { usage(fs) }
+
+	var flags config
+	configFile := fs.String("config", "", "Path of the JSON config file (default $APPLE_MUSIC_CONFIG or applemusic/config.json in the user config directory)")
+	fs.StringVar(&flags.Token, "t", "", "Apple Music API token (developer token)")
+	fs.StringVar(&flags.KeyId, "key-id", "", "MusicKit key identifier, to generate the developer token without -t")
+	fs.StringVar(&flags.TeamId, "team-id", "", "Team ID, to generate the developer token without -t")
+	fs.StringVar(&flags.PrivateKeyFile, "private-key", "", "Path of the MusicKit private key file, to generate the developer token without -t")
+	fs.StringVar(&flags.MusicUserToken, "u", "", "Music user token, required by the library, history and playlist commands")
+	fs.StringVar(&flags.Storefront, "s", "", "Storefront of the catalog (default us)")
+	fs.StringVar(&flags.Output, "o", "", "Output format: table, json or jsonl (default table)")
+	fs.StringVar(&flags.BaseURL, "base-url", "", "Base URL of the API (default https://api.music.apple.com/)")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if _, err := newPrinter(flags.Output, stdout); err != nil {
+		fmt.Fprintln(stderr, "applemusic:", err)
+		return 2
+	}
+
+	cfg, err := loadConfig(*configFile, getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, "applemusic:", err)
+		return 1
+	}
+	cfg.merge(flags)
+
+	name := fs.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "applemusic: unknown command %q\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	c, err := newCLI(ctx, cfg, stdout, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, "applemusic:", err)
+		return 1
+	}
+	c.name, c.usage = name, cmd.usage
+	if err := cmd.run(c, fs.Args()[1:]); err != nil {
+		switch err {
+		case flag.ErrHelp:
+			return 0
+		case errUsage:
+			fmt.Fprintf(stderr, "Usage: applemusic %s %s\n", name, cmd.usage)
+			return 2
+		case errFlags:
+			return 2
+		}
+		fmt.Fprintln(stderr, "applemusic:", err)
+		return 1
+	}
+	return 0
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: applemusic [options] <command> [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].help)
+	}
+
+	fmt.Fprintln(w, "\nOptions:")
+	fs.PrintDefaults()
+}
+
+func newCLI(ctx context.Context, cfg *config, stdout, stderr io.Writer) (*cli, error) {
+	out, err := newPrinter(cfg.Output, stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	tp, err := cfg.transport()
+	if err != nil {
+		return nil, err
+	}
+	client := applemusic.NewClient(tp.Client())
+	if cfg.BaseURL != "" {
+		u, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %v", err)
+		}
+		client.BaseURL = u
+	}
+
+	storefront := cfg.Storefront
+	if storefront == "" {
+		storefront = "us"
+	}
+	return &cli{ctx: ctx, client: client, storefront: storefront, out: out, stdout: stdout, stderr: stderr}, nil
+}
+
+// flagSet returns a new flag.FlagSet of the running command, which prints its errors and usage to the standard error.
+func (c *cli) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: applemusic %s %s\n", c.name, c.usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the arguments of the command.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errFlags
+	}
+	return nil
+}
+
+// resourceTypes parses a comma-separated list of resource types.
+func resourceTypes(s string) []applemusic.ResourceType {
+	var types []applemusic.ResourceType
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, applemusic.ResourceType(t))
+		}
+	}
+	return types
+}
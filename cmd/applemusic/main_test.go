@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minchao/go-apple-music"
+	"github.com/minchao/go-apple-music/applemusictest"
+)
+
+func newTestServer() *applemusictest.Server {
+	s := applemusictest.NewServer()
+	s.SetTokens("developer-token", "music-user-token")
+	s.AddStorefronts(
+		applemusic.Storefront{Id: "us", Attributes: applemusic.StorefrontAttributes{Name: "United States"}},
+		applemusic.Storefront{Id: "jp", Attributes: applemusic.StorefrontAttributes{Name: "Japan"}},
+	)
+	s.AddSongs("us",
+		applemusic.Song{Id: "1", Attributes: applemusic.SongAttributes{Name: "Sunday Bloody Sunday", ArtistName: "U2", ISRC: "GBUM71029604"}},
+		applemusic.Song{Id: "2", Attributes: applemusic.SongAttributes{Name: "New Year's Day", ArtistName: "U2"}},
+	)
+	s.AddSongs("jp", applemusic.Song{Id: "101", Attributes: applemusic.SongAttributes{Name: "Sunday Bloody Sunday", ArtistName: "U2", ISRC: "GBUM71029604"}})
+	s.AddAlbums("us", applemusic.Album{Id: "10", Attributes: applemusic.AlbumAttributes{Name: "War", ArtistName: "U2", UPC: "602517648441"}})
+	s.AddLibrarySongs(
+		applemusic.LibrarySong{Id: "i.1", Attributes: applemusic.LibrarySongAttributes{Name: "Gloria"}},
+		applemusic.LibrarySong{Id: "i.2", Attributes: applemusic.LibrarySongAttributes{Name: "October"}},
+	)
+	return s
+}
+
+// runTest runs the tool against the server without environment, and returns the exit code and the outputs.
+func runTest(s *applemusictest.Server, args ...string) (int, string, string) {
+	args = append([]string{"-config", os.DevNull, "-base-url", s.URL, "-t", "developer-token", "-u", "music-user-token"}, args...)
+	getenv := func(string) string { return "" }
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr, getenv)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_table(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	code, stdout, stderr := runTest(s, "catalog", "get", "songs", "1")
+	if code != 0 {
+		t.Fatalf("run returned %d: %s", code, stderr)
+	}
+	want := "ID  TYPE   NAME                  ARTIST\n" +
+		"1   songs  Sunday Bloody Sunday  U2\n"
+	if stdout != want {
+		t.Errorf("run printed %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_json(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	code, stdout, stderr := runTest(s, "-o", "json", "catalog", "by-ids", "songs", "1,2")
+	if code != 0 {
+		t.Fatalf("run returned %d: %s", code, stderr)
+	}
+	var songs applemusic.Songs
+	if err := json.Unmarshal([]byte(stdout), &songs); err != nil {
+		t.Fatalf("run printed invalid JSON: %v", err)
+	}
+	if len(songs.Data) != 2 || songs.Data[1].Attributes.Name != "New Year's Day" {
+		t.Errorf("run printed %+v, want songs 1 and 2", songs)
+	}
+}
+
+func TestRun_jsonl(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	code, stdout, stderr := runTest(s, "-o", "jsonl", "library", "-limit", "1", "-all", "songs")
+	if code != 0 {
+		t.Fatalf("run returned %d: %s", code, stderr)
+	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		var song applemusic.LibrarySong
+		if err := json.Unmarshal([]byte(line), &song); err != nil {
+			t.Fatalf("run printed invalid JSON line %q: %v", line, err)
+		}
+		names = append(names, song.Attributes.Name)
+	}
+	if want := []string{"Gloria", "October"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("run printed %v, want %v", names, want)
+	}
+}
+
+func TestRun_commands(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"storefronts"}, []string{"Japan", "United States"}},
+		{[]string{"storefronts", "jp"}, []string{"Japan"}},
+		{[]string{"catalog", "by-isrc", "GBUM71029604"}, []string{"Sunday Bloody Sunday"}},
+		{[]string{"catalog", "by-upc", "602517648441"}, []string{"War"}},
+		{[]string{"-s", "jp", "catalog", "equivalents", "songs", "1"}, []string{"101", "Sunday Bloody Sunday"}},
+		{[]string{"search", "-types", "songs,albums", "u2"}, []string{"War", "New Year's Day"}},
+		{[]string{"library", "songs"}, []string{"Gloria", "October"}},
+		{[]string{"availability", "1", "3"}, []string{"1    1/2        us", "3    0/2"}},
+		{[]string{"availability", "-isrc", "-storefronts", "us", "GBUM71029604"}, []string{"GBUM71029604  1/1        us(1)"}},
+		{[]string{"export", "-format", "m3u8"}, []string{"#EXTM3U", "#EXTINF:-1,Gloria"}},
+		{[]string{"playlist", "create", "-name", "Road Trip", "1", "i.1"}, []string{"Road Trip"}},
+	}
+	for _, tt := range tests {
+		code, stdout, stderr := runTest(s, tt.args...)
+		if code != 0 {
+			t.Errorf("run %v returned %d: %s", tt.args, code, stderr)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("run %v printed %q, want %q", tt.args, stdout, want)
+			}
+		}
+	}
+
+	playlists := s.LibraryPlaylists()
+	if len(playlists) != 1 {
+		t.Fatalf("Server has %d library playlists, want 1", len(playlists))
+	}
+	tracks := s.LibraryPlaylistTracks(playlists[0].Id)
+	want := []applemusic.CreateLibraryPlaylistTrack{{Id: "1", Type: "songs"}, {Id: "i.1", Type: "library-songs"}}
+	if !reflect.DeepEqual(tracks, want) {
+		t.Errorf("Server has playlist tracks %+v, want %+v", tracks, want)
+	}
+}
+
+func TestRun_import(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "applemusic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "road-trip.m3u8")
+	data := "#EXTM3U\n#PLAYLIST:Road Trip\n" +
+		"#EXTINF:0,U2 - Sunday Bloody Sunday\nMusic/U2/War/01.m4a\n" +
+		"https://music.apple.com/us/song/2\n" +
+		"#EXTINF:0,Joy Division - Atmosphere\nMusic/Joy Division/Atmosphere.m4a\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runTest(s, "import", "-dry-run", path)
+	if code != 0 {
+		t.Fatalf("run returned %d: %s", code, stderr)
+	}
+	for _, want := range []string{"matched    U2 - Sunday Bloody Sunday", "https://music.apple.com/us/song/2  2 (U2 - New Year's Day)", "unmatched  Joy Division - Atmosphere"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("run printed %q, want %q", stdout, want)
+		}
+	}
+	if len(s.LibraryPlaylists()) != 0 {
+		t.Errorf("run created a playlist in a dry run")
+	}
+
+	code, stdout, stderr = runTest(s, "import", path)
+	if code != 0 {
+		t.Fatalf("run returned %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `"Road Trip": 2 matched, 0 ambiguous, 1 unmatched`) {
+		t.Errorf("run printed %q, want the summary of the import", stdout)
+	}
+	playlists := s.LibraryPlaylists()
+	if len(playlists) != 1 {
+		t.Fatalf("Server has %d library playlists, want 1", len(playlists))
+	}
+	tracks := s.LibraryPlaylistTracks(playlists[0].Id)
+	want := []applemusic.CreateLibraryPlaylistTrack{{Id: "1", Type: "songs"}, {Id: "2", Type: "songs"}}
+	if !reflect.DeepEqual(tracks, want) {
+		t.Errorf("Server has playlist tracks %+v, want %+v", tracks, want)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	s := newTestServer()
+	defer s.Close()
+
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{nil, 2, "Usage: applemusic"},
+		{[]string{"unknown"}, 2, `unknown command "unknown"`},
+		{[]string{"catalog", "get", "songs"}, 2, "Usage: applemusic catalog"},
+		{[]string{"catalog", "get", "unknown", "1"}, 1, `unknown catalog type "unknown"`},
+		{[]string{"search", "-limit", "x", "u2"}, 2, "invalid value"},
+		{[]string{"-o", "xml", "storefronts"}, 2, `unknown output format "xml"`},
+		{[]string{"-base-url", "%", "storefronts"}, 1, "invalid base URL"},
+		{[]string{"catalog", "get", "songs", "404"}, 1, "404"},
+		{[]string{"search", "-limit", "26", "u2"}, 1, "SearchOptions.Limit"},
+	}
+	for _, tt := range tests {
+		code, _, stderr := runTest(s, tt.args...)
+		if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+			t.Errorf("run %v returned %d, %q, want %d, %q", tt.args, code, stderr, tt.code, tt.stderr)
+		}
+	}
+}
+
+func TestRun_privateKeyFlags(t *testing.T) {
+	args := []string{"-config", os.DevNull, "-key-id", "KEY", "-team-id", "TEAM", "-private-key", "missing.p8", "storefronts"}
+	getenv := func(string) string { return "" }
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), args, &stdout, &stderr, getenv); code != 1 || !strings.Contains(stderr.String(), "missing.p8") {
+		t.Errorf("run %v returned %d, %q, want 1, %q", args, code, stderr.String(), "missing.p8")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "applemusic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	data := `{"token":"file-token","keyId":"file-key","teamId":"file-team","privateKeyFile":"file.p8",` +
+		`"musicUserToken":"file-user-token","storefront":"jp","output":"json"}`
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"APPLE_MUSIC_CONFIG":      path,
+		"APPLE_MUSIC_API_TOKEN":   "env-token",
+		"APPLE_MUSIC_TEAM_ID":     "env-team",
+		"APPLE_MUSIC_PRIVATE_KEY": "env.p8",
+	}
+
+	cfg, err := loadConfig("", func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+	cfg.merge(config{Storefront: "gb", PrivateKeyFile: "flag.p8"})
+
+	want := &config{
+		Token:          "env-token",
+		KeyId:          "file-key",
+		TeamId:         "env-team",
+		PrivateKeyFile: "flag.p8",
+		MusicUserToken: "file-user-token",
+		Storefront:     "gb",
+		Output:         "json",
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("loadConfig returned %+v, want %+v", cfg, want)
+	}
+
+	if _, err := loadConfig(filepath.Join(dir, "missing.json"), func(string) string { return "" }); !os.IsNotExist(err) {
+		t.Errorf("loadConfig returned error %v, want not exist", err)
+	}
+}
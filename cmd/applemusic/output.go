@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+)
+
+// printer prints the results of the commands in one of the output formats:
+// a table of the resources, the whole response as indented JSON, or one resource per line as JSON.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "":
+		format = formatTable
+	case formatTable, formatJSON, formatJSONL:
+	default:
+		return nil, fmt.Errorf("unknown output format %q, must be table, json or jsonl", format)
+	}
+	return &printer{format: format, w: w}, nil
+}
+
+// print prints the response v, whose resources are the elements of the slices items.
+func (p *printer) print(v interface{}, items ...interface{}) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatJSONL:
+		enc := json.NewEncoder(p.w)
+		for _, item := range flatten(items) {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return p.table(flatten(items))
+	}
+}
+
+// row is the summary of a resource printed in a table.
+type row struct {
+	Id         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		Name        string `json:"name"`
+		ArtistName  string `json:"artistName"`
+		CuratorName string `json:"curatorName"`
+	} `json:"attributes"`
+}
+
+func (p *printer) table(items []interface{}) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	if len(items) > 0 {
+		if _, ok := items[0].(string); ok {
+			fmt.Fprintln(tw, "TERM")
+			for _, item := range items {
+				fmt.Fprintln(tw, item)
+			}
+			return tw.Flush()
+		}
+	}
+
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tARTIST")
+	for _, item := range items {
+		// Every resource has an identifier, a type and attributes, the name being the most common one.
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		var r row
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		artist := r.Attributes.ArtistName
+		if artist == "" {
+			artist = r.Attributes.CuratorName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Id, r.Type, r.Attributes.Name, artist)
+	}
+	return tw.Flush()
+}
+
+// data returns the Data slice of the collection v, such as *applemusic.Songs, or nil if v is nil.
+func data(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil
+	}
+	return rv.Elem().FieldByName("Data").Interface()
+}
+
+// flatten returns the elements of the slices, skipping the nil ones.
+func flatten(slices []interface{}) []interface{} {
+	var items []interface{}
+	for _, s := range slices {
+		v := reflect.ValueOf(s)
+		if v.Kind() != reflect.Slice {
+			continue
+		}
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i).Interface())
+		}
+	}
+	return items
+}
@@ -0,0 +1,66 @@
+package applemusic
+
+import (
+	"fmt"
+	"time"
+)
+
+// DatePrecision represents the precision of a Date.
+type DatePrecision int
+
+// The precisions of the dates.
+const (
+	DatePrecisionUnknown DatePrecision = iota // The date is empty or not in a known format.
+	DatePrecisionYear                         // YYYY
+	DatePrecisionMonth                        // YYYY-MM
+	DatePrecisionDay                          // YYYY-MM-DD
+	DatePrecisionTime                         // RFC 3339 date and time, such as 2017-09-08T17:00:00Z
+)
+
+var dateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{"2006", DatePrecisionYear},
+	{"2006-01", DatePrecisionMonth},
+	{"2006-01-02", DatePrecisionDay},
+	{time.RFC3339, DatePrecisionTime},
+}
+
+// Date represents a date of a resource, such as the release date of an album.
+// Depending on what is known, it is a year (YYYY), a month (YYYY-MM), a day (YYYY-MM-DD),
+// or an RFC 3339 date and time. It is encoded to JSON as is.
+type Date string
+
+// Time returns the date as a time, in UTC unless the date has a time zone.
+// A date of a year or a month returns the first day of it.
+func (d Date) Time() (time.Time, error) {
+	t, _, err := d.parse()
+	return t, err
+}
+
+// Precision returns the precision of the date, or DatePrecisionUnknown if it is empty or not in a known format.
+func (d Date) Precision() DatePrecision {
+	_, p, _ := d.parse()
+	return p
+}
+
+func (d Date) parse() (time.Time, DatePrecision, error) {
+	for _, l := range dateLayouts {
+		if len(d) != len(l.layout) && l.precision != DatePrecisionTime {
+			continue
+		}
+		if t, err := time.Parse(l.layout, string(d)); err == nil {
+			return t, l.precision, nil
+		}
+	}
+	return time.Time{}, DatePrecisionUnknown, fmt.Errorf("applemusic: invalid date %q", string(d))
+}
+
+// Milliseconds represents a duration in milliseconds, such as the duration of a song.
+type Milliseconds int64
+
+// Duration returns the milliseconds as a time.Duration.
+func (ms Milliseconds) Duration() time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
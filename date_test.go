@@ -0,0 +1,72 @@
+package applemusic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	testCases := []struct {
+		date      Date
+		precision DatePrecision
+		want      time.Time
+	}{
+		{"1983", DatePrecisionYear, time.Date(1983, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"1983-02", DatePrecisionMonth, time.Date(1983, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"1983-02-28", DatePrecisionDay, time.Date(1983, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"2018-01-01T08:30:00Z", DatePrecisionTime, time.Date(2018, 1, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		if got := tc.date.Precision(); got != tc.precision {
+			t.Errorf("Date(%q).Precision = %v, want %v", tc.date, got, tc.precision)
+		}
+		got, err := tc.date.Time()
+		if err != nil {
+			t.Errorf("Date(%q).Time returned error: %v", tc.date, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("Date(%q).Time = %v, want %v", tc.date, got, tc.want)
+		}
+	}
+}
+
+func TestDate_invalid(t *testing.T) {
+	for _, d := range []Date{"", "83", "1983-2-28", "28/02/1983", "1983-02-28 08:30"} {
+		if got := d.Precision(); got != DatePrecisionUnknown {
+			t.Errorf("Date(%q).Precision = %v, want %v", d, got, DatePrecisionUnknown)
+		}
+		if _, err := d.Time(); err == nil {
+			t.Errorf("Date(%q).Time returned no error", d)
+		}
+	}
+}
+
+func TestDate_json(t *testing.T) {
+	for _, s := range []string{
+		`{"releaseDate":"1983","durationInMillis":312000}`,
+		`{"releaseDate":"1983-02-28","durationInMillis":312000}`,
+		`{"releaseDate":"2018-01-01T08:30:00+08:00","durationInMillis":312000}`,
+	} {
+		var v struct {
+			ReleaseDate      Date         `json:"releaseDate"`
+			DurationInMillis Milliseconds `json:"durationInMillis"`
+		}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if string(b) != s {
+			t.Errorf("Marshal = %s, want %s", b, s)
+		}
+	}
+}
+
+func TestMilliseconds_Duration(t *testing.T) {
+	if got, want := Milliseconds(312500).Duration(), 5*time.Minute+12*time.Second+500*time.Millisecond; got != want {
+		t.Errorf("Duration = %v, want %v", got, want)
+	}
+}
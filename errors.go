@@ -0,0 +1,130 @@
+package applemusic
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors that the errors returned for non-successful API responses match with errors.Is.
+var (
+	// ErrBadParameter is matched by Bad Request (400) errors, such as an invalid parameter value.
+	ErrBadParameter = errors.New("applemusic: bad parameter")
+
+	// ErrUnauthorized is matched by Unauthorized (401) errors, such as a missing or expired developer token.
+	ErrUnauthorized = errors.New("applemusic: unauthorized")
+
+	// ErrForbidden is matched by Forbidden (403) errors, such as a missing or invalid music user token.
+	ErrForbidden = errors.New("applemusic: forbidden")
+
+	// ErrNotFound is matched by Not Found (404) errors.
+	ErrNotFound = errors.New("applemusic: not found")
+
+	// ErrRateLimited is matched by Too Many Requests (429) errors.
+	ErrRateLimited = errors.New("applemusic: rate limited")
+
+	// ErrUpstreamUnavailable is matched by server (5xx) errors.
+	ErrUpstreamUnavailable = errors.New("applemusic: upstream unavailable")
+)
+
+// APIError is implemented by the errors returned by CheckResponse:
+// *ErrorResponse, *UnauthorizedError and *TooManyRequestsError.
+type APIError interface {
+	error
+
+	// StatusCode returns the HTTP status code of the response.
+	StatusCode() int
+
+	// Method returns the HTTP method of the request.
+	Method() string
+
+	// URL returns the URL of the request.
+	URL() string
+
+	// ErrorList returns the errors parsed from the response body, if any.
+	ErrorList() []Error
+}
+
+// sentinelError returns the sentinel error corresponding to the HTTP status code, or nil.
+func sentinelError(statusCode int) error {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return ErrBadParameter
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrUpstreamUnavailable
+	}
+	return nil
+}
+
+func responseStatusCode(r *http.Response) int {
+	if r == nil {
+		return 0
+	}
+	return r.StatusCode
+}
+
+func responseMethod(r *http.Response) string {
+	if r == nil || r.Request == nil {
+		return ""
+	}
+	return r.Request.Method
+}
+
+func responseURL(r *http.Response) string {
+	if r == nil || r.Request == nil || r.Request.URL == nil {
+		return ""
+	}
+	return r.Request.URL.String()
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *ErrorResponse) StatusCode() int { return responseStatusCode(r.Response) }
+
+// Method returns the HTTP method of the request.
+func (r *ErrorResponse) Method() string { return responseMethod(r.Response) }
+
+// URL returns the URL of the request.
+func (r *ErrorResponse) URL() string { return responseURL(r.Response) }
+
+// ErrorList returns the errors parsed from the response body.
+func (r *ErrorResponse) ErrorList() []Error { return r.Errors }
+
+// Unwrap returns the sentinel error corresponding to the status code of the response.
+func (r *ErrorResponse) Unwrap() error { return sentinelError(r.StatusCode()) }
+
+// StatusCode returns the HTTP status code of the response.
+func (e *UnauthorizedError) StatusCode() int { return responseStatusCode(e.Response) }
+
+// Method returns the HTTP method of the request.
+func (e *UnauthorizedError) Method() string { return responseMethod(e.Response) }
+
+// URL returns the URL of the request.
+func (e *UnauthorizedError) URL() string { return responseURL(e.Response) }
+
+// ErrorList returns nil, the response body of an Unauthorized error is not an error document.
+func (e *UnauthorizedError) ErrorList() []Error { return nil }
+
+// Unwrap returns ErrUnauthorized.
+func (e *UnauthorizedError) Unwrap() error { return ErrUnauthorized }
+
+// StatusCode returns the HTTP status code of the response.
+func (e *TooManyRequestsError) StatusCode() int { return responseStatusCode(e.Response) }
+
+// Method returns the HTTP method of the request.
+func (e *TooManyRequestsError) Method() string { return responseMethod(e.Response) }
+
+// URL returns the URL of the request.
+func (e *TooManyRequestsError) URL() string { return responseURL(e.Response) }
+
+// ErrorList returns nil, the response body of a Too Many Requests error is not an error document.
+func (e *TooManyRequestsError) ErrorList() []Error { return nil }
+
+// Unwrap returns ErrRateLimited.
+func (e *TooManyRequestsError) Unwrap() error { return ErrRateLimited }
@@ -0,0 +1,101 @@
+package applemusic
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestErrorResponse(statusCode int, body string) *http.Response {
+	u, _ := url.Parse("https://api.music.apple.com/v1/catalog/us/songs/1")
+	return &http.Response{
+		Request: &http.Request{
+			Method: "GET",
+			URL:    u,
+		},
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCheckResponse_sentinels(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		body       string
+		want       error
+	}{
+		{http.StatusBadRequest, `{"errors":[{"status":"400","code":"40005"}]}`, ErrBadParameter},
+		{http.StatusUnauthorized, "Unauthorized", ErrUnauthorized},
+		{http.StatusForbidden, `{"errors":[{"status":"403","code":"40300"}]}`, ErrForbidden},
+		{http.StatusNotFound, "", ErrNotFound},
+		{http.StatusTooManyRequests, `{"message":"API capacity exceeded"}`, ErrRateLimited},
+		{http.StatusInternalServerError, "<html>Internal Server Error</html>", ErrUpstreamUnavailable},
+		{http.StatusServiceUnavailable, "", ErrUpstreamUnavailable},
+	}
+	sentinels := []error{ErrBadParameter, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrUpstreamUnavailable}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			err := CheckResponse(newTestErrorResponse(tc.statusCode, tc.body))
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == tc.want; got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, sentinel, got, want)
+				}
+			}
+
+			var apiErr APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As(%v, APIError) = false, want true", err)
+			}
+			if got := apiErr.StatusCode(); got != tc.statusCode {
+				t.Errorf("APIError.StatusCode = %v, want %v", got, tc.statusCode)
+			}
+			if got, want := apiErr.Method(), "GET"; got != want {
+				t.Errorf("APIError.Method = %v, want %v", got, want)
+			}
+			if got, want := apiErr.URL(), "https://api.music.apple.com/v1/catalog/us/songs/1"; got != want {
+				t.Errorf("APIError.URL = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckResponse_errorList(t *testing.T) {
+	err := CheckResponse(newTestErrorResponse(http.StatusForbidden, `{"errors":[{"status":"403","code":"40300","title":"Forbidden"}]}`))
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As(%v, APIError) = false, want true", err)
+	}
+	want := []Error{{Status: "403", Code: "40300", Title: "Forbidden"}}
+	if got := apiErr.ErrorList(); !reflect.DeepEqual(got, want) {
+		t.Errorf("APIError.ErrorList = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckResponse_nonJSONBody(t *testing.T) {
+	err := CheckResponse(newTestErrorResponse(http.StatusNotFound, "404 page not found\n"))
+
+	errorResponse, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("CheckResponse returned %T, want *ErrorResponse", err)
+	}
+	if got, want := errorResponse.Message, "404 page not found"; got != want {
+		t.Errorf("ErrorResponse.Message = %q, want %q", got, want)
+	}
+	if got, want := err.Error(), "GET https://api.music.apple.com/v1/catalog/us/songs/1: 404 404 page not found"; got != want {
+		t.Errorf("Error = %v, want %v", got, want)
+	}
+}
+
+func TestCheckResponse_statusTooManyRequests_nonJSONBody(t *testing.T) {
+	err := CheckResponse(newTestErrorResponse(http.StatusTooManyRequests, "Too Many Requests"))
+
+	if got, want := err.(*TooManyRequestsError).Message, "Too Many Requests"; got != want {
+		t.Errorf("TooManyRequestsError.Message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,542 @@
+package applemusic
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// exportPageLimit is the number of resources fetched per page by the export, the maximum of the library endpoints.
+const exportPageLimit = 100
+
+// ExportFormat is the file format of a library export.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON exports the library songs, library albums and library playlists with their tracks,
+	// as a JSON object with the songs, albums and playlists members.
+	ExportFormatJSON ExportFormat = "json"
+
+	// ExportFormatCSV exports the library songs and the tracks of the library playlists, one per row.
+	// The playlist column is empty for the library songs.
+	ExportFormatCSV ExportFormat = "csv"
+
+	// ExportFormatM3U8 exports the library songs as an extended M3U playlist encoded in UTF-8.
+	ExportFormatM3U8 ExportFormat = "m3u8"
+
+	// ExportFormatXSPF exports the library songs as an XML Shareable Playlist Format (XSPF) playlist.
+	ExportFormatXSPF ExportFormat = "xspf"
+)
+
+// ExportOptions specifies the parameters to export the library.
+type ExportOptions struct {
+	// The format of the export, ExportFormatJSON by default.
+	Format ExportFormat
+
+	// (Optional) The identifier of a library playlist to export instead of the whole library.
+	// In the JSON format, the playlist is exported as an object with a tracks member.
+	PlaylistId string
+}
+
+// ExportLibrary writes the library of the user to w in the format of opt.
+// All the pages of the library songs, library albums, library playlists and their tracks are fetched,
+// as required by the format, and written as they arrive, so the library is never held in memory at once.
+//
+// The M3U8 and XSPF formats are single playlists. Since the library songs have no file location,
+// the location of a track is its catalog URL when it is known, otherwise the href of the resource.
+func (s *MeService) ExportLibrary(ctx context.Context, w io.Writer, opt *ExportOptions) error {
+	if opt == nil {
+		opt = &ExportOptions{}
+	}
+
+	bw := bufio.NewWriter(w)
+	enc, err := newExportEncoder(opt.Format, bw)
+	if err != nil {
+		return err
+	}
+
+	if opt.PlaylistId != "" {
+		err = s.exportPlaylist(ctx, enc, opt.PlaylistId)
+	} else {
+		err = s.exportLibrary(ctx, enc)
+	}
+	if err != nil {
+		return err
+	}
+	if err := enc.close(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func (s *MeService) exportLibrary(ctx context.Context, enc exportEncoder) error {
+	pageOpt := &PageOptions{Limit: exportPageLimit}
+
+	if enc.include("songs") {
+		songs, _, err := s.GetAllLibrarySongs(ctx, pageOpt)
+		if err != nil {
+			return err
+		}
+		if err := enc.beginList("songs"); err != nil {
+			return err
+		}
+		err = s.walk(ctx, songs, pageOpt, func(page interface{}) error {
+			for i := range page.(*LibrarySongs).Data {
+				if err := enc.song(&page.(*LibrarySongs).Data[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := enc.endList(); err != nil {
+			return err
+		}
+	}
+
+	if enc.include("albums") {
+		albums, _, err := s.GetAllLibraryAlbums(ctx, pageOpt)
+		if err != nil {
+			return err
+		}
+		if err := enc.beginList("albums"); err != nil {
+			return err
+		}
+		err = s.walk(ctx, albums, pageOpt, func(page interface{}) error {
+			for i := range page.(*LibraryAlbums).Data {
+				if err := enc.album(&page.(*LibraryAlbums).Data[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := enc.endList(); err != nil {
+			return err
+		}
+	}
+
+	if enc.include("playlists") {
+		playlists, _, err := s.GetAllLibraryPlaylists(ctx, pageOpt)
+		if err != nil {
+			return err
+		}
+		if err := enc.beginList("playlists"); err != nil {
+			return err
+		}
+		err = s.walk(ctx, playlists, pageOpt, func(page interface{}) error {
+			for i := range page.(*LibraryPlaylists).Data {
+				if err := s.exportPlaylistTracks(ctx, enc, &page.(*LibraryPlaylists).Data[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := enc.endList(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MeService) exportPlaylist(ctx context.Context, enc exportEncoder, id string) error {
+	playlists, _, err := s.GetLibraryPlaylist(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+	if len(playlists.Data) == 0 {
+		return fmt.Errorf("applemusic: library playlist %s not found", id)
+	}
+	return s.exportPlaylistTracks(ctx, enc, &playlists.Data[0])
+}
+
+func (s *MeService) exportPlaylistTracks(ctx context.Context, enc exportEncoder, playlist *LibraryPlaylist) error {
+	u := fmt.Sprintf("v1/me/library/playlists/%s/tracks", playlist.Id)
+	pageOpt := &PageOptions{Limit: exportPageLimit}
+	tracks, _, err := s.getLibraryPlaylistsTracks(ctx, u, pageOpt)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if err := enc.beginPlaylist(playlist); err != nil {
+		return err
+	}
+	// An empty playlist has no tracks relationship, for which the API responds with Not Found (404).
+	if tracks != nil {
+		err = s.walk(ctx, tracks, pageOpt, func(page interface{}) error {
+			for i := range page.(*LibraryPlaylistTracks).Data {
+				if err := enc.track(playlist, &page.(*LibraryPlaylistTracks).Data[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return enc.endPlaylist()
+}
+
+// walk calls fn with the first page of a collection and each of the following pages, without accumulating them.
+// The following pages are fetched with the pageOpt of the first one.
+func (s *MeService) walk(ctx context.Context, first interface{}, pageOpt *PageOptions, fn func(page interface{}) error) error {
+	if err := fn(first); err != nil {
+		return err
+	}
+	return s.client.Paginate(ctx, first, &PaginateOptions{Options: pageOpt}, func(page interface{}, _ *Response) error {
+		return fn(page)
+	})
+}
+
+// exportEncoder writes the resources walked by the export in a format.
+type exportEncoder interface {
+	// include reports whether the format includes the list of the library songs, albums or playlists.
+	include(list string) bool
+
+	beginList(list string) error
+	endList() error
+
+	song(song *LibrarySong) error
+	album(album *LibraryAlbum) error
+
+	beginPlaylist(playlist *LibraryPlaylist) error
+	track(playlist *LibraryPlaylist, song *Song) error
+	endPlaylist() error
+
+	// close writes the end of the export.
+	close() error
+}
+
+func newExportEncoder(format ExportFormat, w io.Writer) (exportEncoder, error) {
+	switch format {
+	case ExportFormatJSON, "":
+		return &jsonExportEncoder{w: w}, nil
+	case ExportFormatCSV:
+		return newCSVExportEncoder(w)
+	case ExportFormatM3U8:
+		return &playlistExportEncoder{w: w, header: writeM3U8Header, entry: writeM3U8Entry}, nil
+	case ExportFormatXSPF:
+		return &playlistExportEncoder{w: w, header: writeXSPFHeader, entry: writeXSPFEntry, footer: writeXSPFFooter}, nil
+	}
+	return nil, &InvalidOptionError{Option: "ExportOptions.Format", Reason: fmt.Sprintf("unknown format %q", format)}
+}
+
+// exportTrack is a library song or a playlist track, as written in the tabular and playlist formats.
+type exportTrack struct {
+	id, catalogId               string
+	name, artistName, albumName string
+	discNumber, trackNumber     int
+	duration                    time.Duration
+	location                    string
+}
+
+func librarySongTrack(song *LibrarySong) exportTrack {
+	a := song.Attributes
+	return exportTrack{
+		id:          song.Id,
+		catalogId:   a.PlayParams.CatalogId,
+		name:        a.Name,
+		artistName:  a.ArtistName,
+		albumName:   a.AlbumName,
+		discNumber:  a.DiscNumber,
+		trackNumber: a.TrackNumber,
+		duration:    a.DurationInMillis.Duration(),
+		location:    song.Href,
+	}
+}
+
+func playlistTrack(song *Song) exportTrack {
+	a := song.Attributes
+	t := exportTrack{
+		id:          song.Id,
+		name:        a.Name,
+		artistName:  a.ArtistName,
+		albumName:   a.AlbumName,
+		discNumber:  a.DiscNumber,
+		trackNumber: a.TrackNumber,
+		duration:    a.DurationInMillis.Duration(),
+		location:    a.URL,
+	}
+	if a.PlayParams != nil && a.PlayParams.CatalogId != "" {
+		t.catalogId = a.PlayParams.CatalogId
+	} else if song.Type == "songs" {
+		t.catalogId = song.Id
+	}
+	if t.location == "" {
+		t.location = song.Href
+	}
+	return t
+}
+
+// jsonExportEncoder writes the resources as they are returned by the API,
+// in the members of an object, or the tracks member of the exported playlist.
+type jsonExportEncoder struct {
+	w      io.Writer
+	err    error
+	object bool // Whether the object of the library is started.
+	sep    bool // Whether a separator is needed before the next element.
+}
+
+func (e *jsonExportEncoder) include(string) bool { return true }
+
+func (e *jsonExportEncoder) beginList(list string) error {
+	if e.object {
+		e.write(",")
+	} else {
+		e.write("{")
+		e.object = true
+	}
+	e.write(strconv.Quote(list) + ":[")
+	e.sep = false
+	return e.err
+}
+
+func (e *jsonExportEncoder) endList() error {
+	e.write("]")
+	return e.err
+}
+
+func (e *jsonExportEncoder) song(song *LibrarySong) error { return e.element(song) }
+
+func (e *jsonExportEncoder) album(album *LibraryAlbum) error { return e.element(album) }
+
+func (e *jsonExportEncoder) beginPlaylist(playlist *LibraryPlaylist) error {
+	data, err := json.Marshal(playlist)
+	if err != nil {
+		return err
+	}
+	if e.sep {
+		e.write(",")
+	}
+	// Open the tracks member inside the object of the playlist.
+	e.write(string(data[:len(data)-1]) + `,"tracks":[`)
+	e.sep = false
+	return e.err
+}
+
+func (e *jsonExportEncoder) track(_ *LibraryPlaylist, song *Song) error { return e.element(song) }
+
+func (e *jsonExportEncoder) endPlaylist() error {
+	e.write("]}")
+	e.sep = true
+	return e.err
+}
+
+func (e *jsonExportEncoder) element(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if e.sep {
+		e.write(",")
+	}
+	e.write(string(data))
+	e.sep = true
+	return e.err
+}
+
+func (e *jsonExportEncoder) close() error {
+	if e.object {
+		e.write("}")
+	}
+	e.write("\n")
+	return e.err
+}
+
+func (e *jsonExportEncoder) write(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s)
+	}
+}
+
+// csvExportHeader is the header row of the CSV format.
+var csvExportHeader = []string{
+	"playlist", "id", "catalog_id", "name", "artist_name", "album_name",
+	"disc_number", "track_number", "duration_ms",
+}
+
+// csvExportEncoder writes the library songs and the playlist tracks, one per row.
+type csvExportEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVExportEncoder(w io.Writer) (*csvExportEncoder, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvExportHeader); err != nil {
+		return nil, err
+	}
+	return &csvExportEncoder{w: cw}, nil
+}
+
+func (e *csvExportEncoder) include(list string) bool { return list != "albums" }
+
+func (e *csvExportEncoder) beginList(string) error { return nil }
+
+func (e *csvExportEncoder) endList() error { return nil }
+
+func (e *csvExportEncoder) song(song *LibrarySong) error { return e.row("", librarySongTrack(song)) }
+
+func (e *csvExportEncoder) album(*LibraryAlbum) error { return nil }
+
+func (e *csvExportEncoder) beginPlaylist(*LibraryPlaylist) error { return nil }
+
+func (e *csvExportEncoder) track(playlist *LibraryPlaylist, song *Song) error {
+	return e.row(playlist.Attributes.Name, playlistTrack(song))
+}
+
+func (e *csvExportEncoder) endPlaylist() error { return nil }
+
+func (e *csvExportEncoder) row(playlist string, t exportTrack) error {
+	return e.w.Write([]string{
+		playlist, t.id, t.catalogId, t.name, t.artistName, t.albumName,
+		strconv.Itoa(t.discNumber), strconv.Itoa(t.trackNumber), strconv.FormatInt(int64(t.duration/time.Millisecond), 10),
+	})
+}
+
+func (e *csvExportEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// playlistExportEncoder writes the library songs, or the tracks of the exported playlist, as a single playlist.
+// The header is written before the first entry, once the title of the playlist is known.
+type playlistExportEncoder struct {
+	w      io.Writer
+	title  string
+	begun  bool
+	header func(w io.Writer, title string) error
+	entry  func(w io.Writer, t exportTrack) error
+	footer func(w io.Writer) error
+}
+
+func (e *playlistExportEncoder) include(list string) bool { return list == "songs" }
+
+func (e *playlistExportEncoder) beginList(string) error {
+	e.title = "Library"
+	return nil
+}
+
+func (e *playlistExportEncoder) endList() error { return nil }
+
+func (e *playlistExportEncoder) song(song *LibrarySong) error { return e.write(librarySongTrack(song)) }
+
+func (e *playlistExportEncoder) album(*LibraryAlbum) error { return nil }
+
+func (e *playlistExportEncoder) beginPlaylist(playlist *LibraryPlaylist) error {
+	e.title = playlist.Attributes.Name
+	return nil
+}
+
+func (e *playlistExportEncoder) track(_ *LibraryPlaylist, song *Song) error {
+	return e.write(playlistTrack(song))
+}
+
+func (e *playlistExportEncoder) endPlaylist() error { return nil }
+
+func (e *playlistExportEncoder) write(t exportTrack) error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	return e.entry(e.w, t)
+}
+
+func (e *playlistExportEncoder) begin() error {
+	if e.begun {
+		return nil
+	}
+	e.begun = true
+	return e.header(e.w, e.title)
+}
+
+func (e *playlistExportEncoder) close() error {
+	if err := e.begin(); err != nil {
+		return err
+	}
+	if e.footer == nil {
+		return nil
+	}
+	return e.footer(e.w)
+}
+
+func writeM3U8Header(w io.Writer, title string) error {
+	_, err := fmt.Fprintf(w, "#EXTM3U\n#PLAYLIST:%s\n", title)
+	return err
+}
+
+func writeM3U8Entry(w io.Writer, t exportTrack) error {
+	// The duration is in seconds, -1 when unknown.
+	seconds := -1
+	if t.duration > 0 {
+		seconds = int(math.Round(t.duration.Seconds()))
+	}
+	title := t.name
+	if t.artistName != "" {
+		title = t.artistName + " - " + t.name
+	}
+	_, err := fmt.Fprintf(w, "#EXTINF:%d,%s\n%s\n", seconds, title, t.location)
+	return err
+}
+
+// xspfTrack is a track element of an XSPF playlist.
+type xspfTrack struct {
+	XMLName    xml.Name `xml:"track"`
+	Location   string   `xml:"location,omitempty"`
+	Identifier string   `xml:"identifier,omitempty"`
+	Title      string   `xml:"title,omitempty"`
+	Creator    string   `xml:"creator,omitempty"`
+	Album      string   `xml:"album,omitempty"`
+	TrackNum   int      `xml:"trackNum,omitempty"`
+	Duration   int64    `xml:"duration,omitempty"` // In milliseconds.
+}
+
+func writeXSPFHeader(w io.Writer, title string) error {
+	if _, err := io.WriteString(w, xml.Header+`<playlist version="1" xmlns="http://xspf.org/ns/0/">`+"\n  <title>"); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(w, []byte(title)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</title>\n  <trackList>\n")
+	return err
+}
+
+func writeXSPFEntry(w io.Writer, t exportTrack) error {
+	track := xspfTrack{
+		Location: t.location,
+		Title:    t.name,
+		Creator:  t.artistName,
+		Album:    t.albumName,
+		TrackNum: t.trackNumber,
+		Duration: int64(t.duration / time.Millisecond),
+	}
+	if t.catalogId != "" {
+		track.Identifier = "https://music.apple.com/song/" + t.catalogId
+	}
+	data, err := xml.MarshalIndent(track, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func writeXSPFFooter(w io.Writer) error {
+	_, err := io.WriteString(w, "  </trackList>\n</playlist>\n")
+	return err
+}
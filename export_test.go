@@ -0,0 +1,228 @@
+package applemusic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func setupExport(t *testing.T) {
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("offset") == "" {
+			testFormValues(t, r, values{"limit": "100"})
+			fmt.Fprint(w, `{
+    "data": [
+        {
+            "id": "i.1",
+            "type": "library-songs",
+            "href": "/v1/me/library/songs/i.1",
+            "attributes": {
+                "albumName": "War",
+                "artistName": "U2",
+                "durationInMillis": 280400,
+                "name": "Sunday Bloody Sunday",
+                "playParams": {"id": "i.1", "kind": "song", "isLibrary": true, "catalogId": "1440833098"},
+                "trackNumber": 1
+            }
+        }
+    ],
+    "next": "/v1/me/library/songs?offset=1"
+}`)
+			return
+		}
+		fmt.Fprint(w, `{
+    "data": [
+        {
+            "id": "i.2",
+            "type": "library-songs",
+            "href": "/v1/me/library/songs/i.2",
+            "attributes": {"albumName": "Power, Corruption & Lies", "artistName": "New Order", "name": "Age of Consent"}
+        }
+    ]
+}`)
+	})
+	mux.HandleFunc("/v1/me/library/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"id": "l.1", "type": "library-albums", "attributes": {"name": "War", "artistName": "U2"}}]}`)
+	})
+	mux.HandleFunc("/v1/me/library/playlists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+    "data": [
+        {"id": "p.1", "type": "library-playlists", "href": "/v1/me/library/playlists/p.1", "attributes": {"name": "Road Trip"}},
+        {"id": "p.2", "type": "library-playlists", "href": "/v1/me/library/playlists/p.2", "attributes": {"name": "Empty"}}
+    ]
+}`)
+	})
+	mux.HandleFunc("/v1/me/library/playlists/p.1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data": [{"id": "p.1", "type": "library-playlists", "href": "/v1/me/library/playlists/p.1", "attributes": {"name": "Road Trip"}}]}`)
+	})
+	mux.HandleFunc("/v1/me/library/playlists/p.1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+    "data": [
+        {
+            "id": "1440833098",
+            "type": "songs",
+            "href": "/v1/catalog/us/songs/1440833098",
+            "attributes": {
+                "artistName": "U2",
+                "durationInMillis": 280400,
+                "name": "Sunday Bloody Sunday",
+                "url": "https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098"
+            }
+        }
+    ]
+}`)
+	})
+	mux.HandleFunc("/v1/me/library/playlists/p.2/tracks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": [{"status": "404", "title": "Not Found"}]}`)
+	})
+}
+
+func TestMeService_ExportLibrary_json(t *testing.T) {
+	setup()
+	defer teardown()
+	setupExport(t)
+
+	var buf bytes.Buffer
+	if err := client.Me.ExportLibrary(context.Background(), &buf, nil); err != nil {
+		t.Fatalf("Me.ExportLibrary returned error: %v", err)
+	}
+
+	var got struct {
+		Songs     []LibrarySong  `json:"songs"`
+		Albums    []LibraryAlbum `json:"albums"`
+		Playlists []struct {
+			Id     string `json:"id"`
+			Tracks []Song `json:"tracks"`
+		} `json:"playlists"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Me.ExportLibrary wrote invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(got.Songs) != 2 || got.Songs[1].Id != "i.2" {
+		t.Errorf("Me.ExportLibrary songs = %+v, want i.1 and i.2", got.Songs)
+	}
+	if len(got.Albums) != 1 || got.Albums[0].Attributes.Name != "War" {
+		t.Errorf("Me.ExportLibrary albums = %+v, want War", got.Albums)
+	}
+	if len(got.Playlists) != 2 || len(got.Playlists[0].Tracks) != 1 || len(got.Playlists[1].Tracks) != 0 {
+		t.Errorf("Me.ExportLibrary playlists = %+v, want p.1 with 1 track and p.2 empty", got.Playlists)
+	}
+}
+
+func TestMeService_ExportLibrary_csv(t *testing.T) {
+	setup()
+	defer teardown()
+	setupExport(t)
+
+	var buf bytes.Buffer
+	if err := client.Me.ExportLibrary(context.Background(), &buf, &ExportOptions{Format: ExportFormatCSV}); err != nil {
+		t.Fatalf("Me.ExportLibrary returned error: %v", err)
+	}
+
+	want := `playlist,id,catalog_id,name,artist_name,album_name,disc_number,track_number,duration_ms
+,i.1,1440833098,Sunday Bloody Sunday,U2,War,0,1,280400
+,i.2,,Age of Consent,New Order,"Power, Corruption & Lies",0,0,0
+Road Trip,1440833098,1440833098,Sunday Bloody Sunday,U2,,0,0,280400
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Me.ExportLibrary wrote %q, want %q", got, want)
+	}
+}
+
+func TestMeService_ExportLibrary_m3u8(t *testing.T) {
+	setup()
+	defer teardown()
+	setupExport(t)
+
+	var buf bytes.Buffer
+	if err := client.Me.ExportLibrary(context.Background(), &buf, &ExportOptions{Format: ExportFormatM3U8}); err != nil {
+		t.Fatalf("Me.ExportLibrary returned error: %v", err)
+	}
+
+	want := `#EXTM3U
+#PLAYLIST:Library
+#EXTINF:280,U2 - Sunday Bloody Sunday
+/v1/me/library/songs/i.1
+#EXTINF:-1,New Order - Age of Consent
+/v1/me/library/songs/i.2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Me.ExportLibrary wrote %q, want %q", got, want)
+	}
+}
+
+func TestMeService_ExportLibrary_xspfPlaylist(t *testing.T) {
+	setup()
+	defer teardown()
+	setupExport(t)
+
+	var buf bytes.Buffer
+	opt := &ExportOptions{Format: ExportFormatXSPF, PlaylistId: "p.1"}
+	if err := client.Me.ExportLibrary(context.Background(), &buf, opt); err != nil {
+		t.Fatalf("Me.ExportLibrary returned error: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>Road Trip</title>
+  <trackList>
+    <track>
+      <location>https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098</location>
+      <identifier>https://music.apple.com/song/1440833098</identifier>
+      <title>Sunday Bloody Sunday</title>
+      <creator>U2</creator>
+      <duration>280400</duration>
+    </track>
+  </trackList>
+</playlist>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Me.ExportLibrary wrote %q, want %q", got, want)
+	}
+}
+
+func TestMeService_ExportLibrary_jsonPlaylist(t *testing.T) {
+	setup()
+	defer teardown()
+	setupExport(t)
+
+	var buf bytes.Buffer
+	if err := client.Me.ExportLibrary(context.Background(), &buf, &ExportOptions{PlaylistId: "p.1"}); err != nil {
+		t.Fatalf("Me.ExportLibrary returned error: %v", err)
+	}
+
+	var got struct {
+		Id     string `json:"id"`
+		Tracks []Song `json:"tracks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Me.ExportLibrary wrote invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Id != "p.1" || len(got.Tracks) != 1 || got.Tracks[0].Id != "1440833098" {
+		t.Errorf("Me.ExportLibrary = %+v, want p.1 with 1 track", got)
+	}
+}
+
+func TestMeService_ExportLibrary_invalidFormat(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	err := client.Me.ExportLibrary(context.Background(), &buf, &ExportOptions{Format: "xml"})
+	if !errors.Is(err, ErrBadParameter) {
+		t.Errorf("Me.ExportLibrary returned error %v, want ErrBadParameter", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Me.ExportLibrary wrote %q, want nothing", buf.Bytes())
+	}
+}
@@ -0,0 +1,700 @@
+package applemusic
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// importSearchLimit is the number of songs searched for an entry without catalog identifier or ISRC.
+	importSearchLimit = 10
+
+	// importMatchScore is the minimum score of a searched song to match an entry.
+	importMatchScore = 0.8
+
+	// importMinScore is the minimum score of a searched song to be a candidate of an ambiguous entry.
+	importMinScore = 0.5
+
+	// importAmbiguityMargin is the score below the best one within which other songs make an entry ambiguous.
+	importAmbiguityMargin = 0.05
+
+	// importTracksPerRequest is the number of tracks added to the playlist per request.
+	importTracksPerRequest = 100
+)
+
+// PlaylistEntry is an entry of a playlist file, as parsed by ParsePlaylist.
+type PlaylistEntry struct {
+	Title      string
+	ArtistName string
+	AlbumName  string
+	ISRC       string
+	Duration   time.Duration
+
+	// The identifier of the catalog song the entry refers to, such as the song of an Apple Music URL.
+	CatalogId string
+
+	// The location of the entry in the playlist file, such as a file path or a URL.
+	Location string
+}
+
+// ParsePlaylist parses a playlist file in the M3U (or M3U8), CSV or XSPF format,
+// as written by MeService.ExportLibrary, and returns its title, if any, and its entries.
+//
+// The CSV file must have a header row with a name or title column.
+// The artist_name (or artist), album_name (or album), isrc, catalog_id and duration_ms columns are optional.
+func ParsePlaylist(r io.Reader, format ExportFormat) (string, []PlaylistEntry, error) {
+	switch format {
+	case ExportFormatM3U8:
+		return parseM3U(r)
+	case ExportFormatCSV:
+		return parseCSVPlaylist(r)
+	case ExportFormatXSPF:
+		return parseXSPF(r)
+	}
+	return "", nil, &InvalidOptionError{Option: "ImportOptions.Format", Reason: fmt.Sprintf("unsupported format %q", format)}
+}
+
+func parseM3U(r io.Reader) (string, []PlaylistEntry, error) {
+	var (
+		title   string
+		entries []PlaylistEntry
+		extinf  *PlaylistEntry
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff"))
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			e := parseExtinf(strings.TrimPrefix(line, "#EXTINF:"))
+			extinf = &e
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			title = strings.TrimSpace(strings.TrimPrefix(line, "#PLAYLIST:"))
+		case strings.HasPrefix(line, "#"):
+		default:
+			var e PlaylistEntry
+			if extinf != nil {
+				e, extinf = *extinf, nil
+			}
+			e.Location = line
+			e.CatalogId = catalogIdFromURL(line)
+			if e.Title == "" && e.CatalogId == "" {
+				// Without #EXTINF, a file named like "Artist - Title.mp3" is the best hint.
+				name := path.Base(strings.Replace(line, `\`, "/", -1))
+				e.ArtistName, e.Title = splitArtistTitle(strings.TrimSuffix(name, path.Ext(name)))
+			}
+			entries = append(entries, e)
+		}
+	}
+	return title, entries, sc.Err()
+}
+
+// parseExtinf parses the value of an #EXTINF directive, the duration in seconds and the "Artist - Title" display title.
+func parseExtinf(s string) PlaylistEntry {
+	var e PlaylistEntry
+	i := strings.Index(s, ",")
+	if i < 0 {
+		return e
+	}
+	// The duration may be followed by attributes, such as tvg-id="x".
+	if fields := strings.Fields(s[:i]); len(fields) > 0 {
+		if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil && seconds > 0 {
+			e.Duration = time.Duration(seconds * float64(time.Second))
+		}
+	}
+	e.ArtistName, e.Title = splitArtistTitle(strings.TrimSpace(s[i+1:]))
+	return e
+}
+
+func splitArtistTitle(s string) (string, string) {
+	if i := strings.Index(s, " - "); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+3:])
+	}
+	return "", s
+}
+
+func parseCSVPlaylist(r io.Reader) (string, []PlaylistEntry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[strings.Replace(name, " ", "_", -1)] = i
+	}
+	column := func(names ...string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	titleCol := column("name", "title")
+	if titleCol < 0 {
+		return "", nil, errors.New("applemusic: CSV playlist has no name or title column")
+	}
+	artistCol := column("artist_name", "artist")
+	albumCol := column("album_name", "album")
+	isrcCol := column("isrc")
+	catalogIdCol := column("catalog_id")
+	durationCol := column("duration_ms")
+
+	var entries []PlaylistEntry
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		e := PlaylistEntry{
+			Title:      field(titleCol),
+			ArtistName: field(artistCol),
+			AlbumName:  field(albumCol),
+			ISRC:       field(isrcCol),
+			CatalogId:  field(catalogIdCol),
+		}
+		if ms, err := strconv.ParseInt(field(durationCol), 10, 64); err == nil {
+			e.Duration = Milliseconds(ms).Duration()
+		}
+		entries = append(entries, e)
+	}
+	return "", entries, nil
+}
+
+func parseXSPF(r io.Reader) (string, []PlaylistEntry, error) {
+	var playlist struct {
+		Title  string `xml:"title"`
+		Tracks []struct {
+			Locations   []string `xml:"location"`
+			Identifiers []string `xml:"identifier"`
+			Title       string   `xml:"title"`
+			Creator     string   `xml:"creator"`
+			Album       string   `xml:"album"`
+			Duration    int64    `xml:"duration"`
+		} `xml:"trackList>track"`
+	}
+	if err := xml.NewDecoder(r).Decode(&playlist); err != nil {
+		return "", nil, err
+	}
+
+	entries := make([]PlaylistEntry, 0, len(playlist.Tracks))
+	for _, track := range playlist.Tracks {
+		e := PlaylistEntry{
+			Title:      strings.TrimSpace(track.Title),
+			ArtistName: strings.TrimSpace(track.Creator),
+			AlbumName:  strings.TrimSpace(track.Album),
+			Duration:   Milliseconds(track.Duration).Duration(),
+		}
+		for _, id := range append(track.Identifiers, track.Locations...) {
+			id = strings.TrimSpace(id)
+			if isrc := strings.TrimPrefix(strings.TrimPrefix(id, "urn:"), "isrc:"); isrc != id {
+				e.ISRC = isrc
+			} else if e.CatalogId == "" {
+				e.CatalogId = catalogIdFromURL(id)
+			}
+		}
+		if len(track.Locations) > 0 {
+			e.Location = strings.TrimSpace(track.Locations[0])
+		}
+		entries = append(entries, e)
+	}
+	return strings.TrimSpace(playlist.Title), entries, nil
+}
+
+// catalogIdFromURL returns the identifier of the catalog song of an Apple Music URL, such as
+// https://music.apple.com/us/album/war/1440833083?i=1440833098 or https://music.apple.com/us/song/1440833098.
+func catalogIdFromURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || !strings.HasSuffix(u.Host, "music.apple.com") && !strings.HasSuffix(u.Host, "itunes.apple.com") {
+		return ""
+	}
+	if i := u.Query().Get("i"); isDigits(i) {
+		return i
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	last := segments[len(segments)-1]
+	for _, segment := range segments[:len(segments)-1] {
+		if segment == "song" && isDigits(last) {
+			return last
+		}
+	}
+	return ""
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// ImportStatus is the outcome of matching a playlist entry with the catalog.
+type ImportStatus int
+
+const (
+	// ImportUnmatched is the status of an entry without any matching song.
+	ImportUnmatched ImportStatus = iota
+
+	// ImportMatched is the status of an entry matched by catalog identifier, ISRC or a clearly best search result.
+	ImportMatched
+
+	// ImportAmbiguous is the status of an entry with several songs matching about as well.
+	ImportAmbiguous
+)
+
+func (s ImportStatus) String() string {
+	switch s {
+	case ImportMatched:
+		return "matched"
+	case ImportAmbiguous:
+		return "ambiguous"
+	}
+	return "unmatched"
+}
+
+// MarshalText encodes the status as its name, such as "matched".
+func (s ImportStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ImportResult is the result of matching a playlist entry with the catalog.
+type ImportResult struct {
+	Entry  PlaylistEntry
+	Status ImportStatus
+
+	// The matched song, or the best candidate of an ambiguous entry.
+	Song *Song
+
+	// The score of Song, from 0 to 1. The songs matched by catalog identifier or ISRC score 1.
+	Score float64
+
+	// The songs an ambiguous entry may match, best first.
+	Candidates []Song
+}
+
+// ImportReport is the report of a playlist import.
+type ImportReport struct {
+	// The name of the created playlist.
+	Name string
+
+	// The created library playlist, nil in a dry run.
+	Playlist *LibraryPlaylist
+
+	// The results of the entries of the playlist file, in order.
+	Results []ImportResult
+}
+
+// Matched returns the results of the matched entries.
+func (r *ImportReport) Matched() []ImportResult { return r.filter(ImportMatched) }
+
+// Ambiguous returns the results of the ambiguous entries.
+func (r *ImportReport) Ambiguous() []ImportResult { return r.filter(ImportAmbiguous) }
+
+// Unmatched returns the results of the unmatched entries.
+func (r *ImportReport) Unmatched() []ImportResult { return r.filter(ImportUnmatched) }
+
+func (r *ImportReport) filter(status ImportStatus) []ImportResult {
+	var results []ImportResult
+	for _, result := range r.Results {
+		if result.Status == status {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// ImportOptions specifies the parameters to import a playlist.
+type ImportOptions struct {
+	// The format of the playlist file: ExportFormatM3U8, ExportFormatCSV or ExportFormatXSPF.
+	Format ExportFormat
+
+	// The name of the created playlist. If empty, the title of the playlist file is used, which is then required.
+	Name string
+
+	// (Optional) The description of the created playlist.
+	Description string
+
+	// If true, the entries are matched and reported, but no playlist is created.
+	DryRun bool
+
+	// If true, the best candidates of the ambiguous entries are added to the playlist as well.
+	IncludeAmbiguous bool
+}
+
+// ImportPlaylist parses a playlist file from r, matches its entries with the songs of the catalog of the storefront,
+// and creates a library playlist of the matched songs, in order. See MatchPlaylistEntries for the matching.
+//
+// The report tells the matched, ambiguous and unmatched entries. In a dry run, nothing is created.
+func (c *Client) ImportPlaylist(ctx context.Context, storefront string, r io.Reader, opt *ImportOptions) (*ImportReport, error) {
+	if opt == nil {
+		opt = &ImportOptions{}
+	}
+
+	title, entries, err := ParsePlaylist(r, opt.Format)
+	if err != nil {
+		return nil, err
+	}
+	report := &ImportReport{Name: opt.Name}
+	if report.Name == "" {
+		report.Name = title
+	}
+	if report.Name == "" && !opt.DryRun {
+		return nil, &InvalidOptionError{Option: "ImportOptions.Name", Reason: "must not be empty when the playlist file has no title"}
+	}
+
+	report.Results, err = c.MatchPlaylistEntries(ctx, storefront, entries)
+	if err != nil {
+		return nil, err
+	}
+	if opt.DryRun {
+		return report, nil
+	}
+
+	var tracks []CreateLibraryPlaylistTrack
+	for _, result := range report.Results {
+		if result.Status == ImportMatched || result.Status == ImportAmbiguous && opt.IncludeAmbiguous {
+			tracks = append(tracks, CreateLibraryPlaylistTrack{Id: result.Song.Id, Type: "songs"})
+		}
+	}
+
+	first := tracks
+	if len(first) > importTracksPerRequest {
+		first = first[:importTracksPerRequest]
+	}
+	body := CreateLibraryPlaylist{
+		Attributes: CreateLibraryPlaylistAttributes{Name: report.Name, Description: opt.Description},
+	}
+	if len(first) > 0 {
+		body.Relationships = &CreateLibraryPlaylistRelationships{Tracks: CreateLibraryPlaylistTrackData{Data: first}}
+	}
+	playlists, _, err := c.Me.CreateLibraryPlaylist(ctx, body, nil)
+	if err != nil {
+		return report, err
+	}
+	if len(playlists.Data) == 0 {
+		return report, errors.New("applemusic: no library playlist in the response of the creation")
+	}
+	report.Playlist = &playlists.Data[0]
+
+	for rest := tracks[len(first):]; len(rest) > 0; {
+		n := importTracksPerRequest
+		if len(rest) < n {
+			n = len(rest)
+		}
+		data := CreateLibraryPlaylistTrackData{Data: rest[:n]}
+		if _, err := c.Me.AddLibraryTracksToPlaylist(ctx, report.Playlist.Id, data); err != nil {
+			return report, err
+		}
+		rest = rest[n:]
+	}
+	return report, nil
+}
+
+// MatchPlaylistEntries matches the playlist entries with the songs of the catalog of the storefront.
+// The entries are matched by catalog identifier first, then by ISRC with GetSongsByIsrcs.
+// The other entries are searched by title and artist name, and the results are scored by the similarity
+// of their title, artist name and duration: the entry matches the best song if it scores high enough
+// and clearly better than the others, and it is ambiguous if other songs score about as well.
+func (c *Client) MatchPlaylistEntries(ctx context.Context, storefront string, entries []PlaylistEntry) ([]ImportResult, error) {
+	results := make([]ImportResult, len(entries))
+	for i, e := range entries {
+		results[i].Entry = e
+	}
+
+	if err := c.matchByIds(ctx, storefront, results); err != nil {
+		return nil, err
+	}
+	if err := c.matchByIsrcs(ctx, storefront, results); err != nil {
+		return nil, err
+	}
+	if err := c.matchBySearch(ctx, storefront, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *Client) matchByIds(ctx context.Context, storefront string, results []ImportResult) error {
+	var ids []string
+	seen := map[string]bool{}
+	for _, result := range results {
+		if id := result.Entry.CatalogId; id != "" && !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	songs, _, err := c.Catalog.GetSongsByIds(ctx, storefront, ids, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	byId := map[string]*Song{}
+	for i := range songs.Data {
+		byId[songs.Data[i].Id] = &songs.Data[i]
+	}
+	for i := range results {
+		if song, ok := byId[results[i].Entry.CatalogId]; ok {
+			results[i].Status, results[i].Song, results[i].Score = ImportMatched, song, 1
+		}
+	}
+	return nil
+}
+
+func (c *Client) matchByIsrcs(ctx context.Context, storefront string, results []ImportResult) error {
+	var isrcs []string
+	seen := map[string]bool{}
+	for _, result := range results {
+		if isrc := strings.ToUpper(result.Entry.ISRC); result.Song == nil && isrc != "" && !seen[isrc] {
+			isrcs = append(isrcs, isrc)
+			seen[isrc] = true
+		}
+	}
+	if len(isrcs) == 0 {
+		return nil
+	}
+
+	songs, _, err := c.Catalog.GetSongsByIsrcs(ctx, storefront, isrcs, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	byIsrc := map[string][]Song{}
+	for _, song := range songs.Data {
+		isrc := strings.ToUpper(song.Attributes.ISRC)
+		byIsrc[isrc] = append(byIsrc[isrc], song)
+	}
+	for i := range results {
+		r := &results[i]
+		candidates := byIsrc[strings.ToUpper(r.Entry.ISRC)]
+		if r.Song != nil || len(candidates) == 0 {
+			continue
+		}
+		// The same recording may be on several albums, prefer the one closest to the entry.
+		best := rankSongs(r.Entry, candidates)[0]
+		r.Status, r.Song, r.Score = ImportMatched, &best.song, 1
+	}
+	return nil
+}
+
+func (c *Client) matchBySearch(ctx context.Context, storefront string, results []ImportResult) error {
+	var pending []*ImportResult
+	for i := range results {
+		if results[i].Song == nil && results[i].Entry.Title != "" {
+			pending = append(pending, &results[i])
+		}
+	}
+
+	_, err := forEachConcurrently(ctx, len(pending), c.BatchConcurrency, func(ctx context.Context, i int) error {
+		return c.matchResult(ctx, storefront, pending[i])
+	})
+	return err
+}
+
+func (c *Client) matchResult(ctx context.Context, storefront string, r *ImportResult) error {
+	search, _, err := c.Catalog.Search(ctx, storefront, &SearchOptions{
+		Term:  strings.TrimSpace(r.Entry.Title + " " + r.Entry.ArtistName),
+		Types: []ResourceType{ResourceTypeSongs},
+		Limit: importSearchLimit,
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if search.Results.Songs == nil {
+		return nil
+	}
+
+	ranked := rankSongs(r.Entry, search.Results.Songs.Data)
+	if len(ranked) == 0 || ranked[0].score < importMinScore {
+		return nil
+	}
+
+	best := ranked[0]
+	r.Song, r.Score = &best.song, best.score
+	for _, other := range ranked[1:] {
+		if other.score >= importMinScore && other.score > best.score-importAmbiguityMargin {
+			r.Candidates = append(r.Candidates, other.song)
+		}
+	}
+	if best.score >= importMatchScore && len(r.Candidates) == 0 {
+		r.Status = ImportMatched
+		return nil
+	}
+	r.Status = ImportAmbiguous
+	r.Candidates = append([]Song{best.song}, r.Candidates...)
+	return nil
+}
+
+// scoredSong is a candidate song of a playlist entry and its score.
+type scoredSong struct {
+	song  Song
+	score float64
+}
+
+// rankSongs returns the songs sorted by their score for the entry, best first, the songs of the album of the entry
+// first among equals. The songs with the ISRC of a better one, the same recording on another album, are dropped.
+func rankSongs(e PlaylistEntry, songs []Song) []scoredSong {
+	ranked := make([]scoredSong, 0, len(songs))
+	for _, song := range songs {
+		ranked = append(ranked, scoredSong{song: song, score: matchScore(e, &song)})
+	}
+	onAlbum := func(s scoredSong) bool {
+		return e.AlbumName != "" && strings.EqualFold(e.AlbumName, s.song.Attributes.AlbumName)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return onAlbum(ranked[i]) && !onAlbum(ranked[j])
+	})
+
+	seen := map[string]bool{}
+	unique := ranked[:0]
+	for _, s := range ranked {
+		if isrc := s.song.Attributes.ISRC; isrc != "" {
+			if seen[isrc] {
+				continue
+			}
+			seen[isrc] = true
+		}
+		unique = append(unique, s)
+	}
+	return unique
+}
+
+// matchScore returns how well the song matches the entry, from 0 to 1,
+// weighting the similarity of the titles, artist names and durations, when they are known.
+func matchScore(e PlaylistEntry, song *Song) float64 {
+	score := 0.5 * titleSimilarity(e.Title, song.Attributes.Name)
+	weight := 0.5
+	if e.ArtistName != "" {
+		score += 0.3 * artistSimilarity(e.ArtistName, song.Attributes.ArtistName)
+		weight += 0.3
+	}
+	if e.Duration > 0 && song.Attributes.DurationInMillis > 0 {
+		score += 0.2 * durationSimilarity(e.Duration, song.Attributes.DurationInMillis.Duration())
+		weight += 0.2
+	}
+	return score / weight
+}
+
+// titleSimilarity returns the Jaccard index of the words of the titles, without their parenthesized parts,
+// such as (Remastered) or [feat. Artist].
+func titleSimilarity(a, b string) float64 {
+	wa, wb := words(stripParenthesized(a)), words(stripParenthesized(b))
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
+
+// artistSimilarity returns the share of the words of the shorter artist name found in the other one,
+// so that the main artist matches the artist name with featured artists.
+func artistSimilarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa) > len(wb) {
+		wa, wb = wb, wa
+	}
+	if len(wa) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wa))
+}
+
+// durationSimilarity is 1 for durations within 2 seconds, decreasing to 0 for durations 20 seconds apart.
+func durationSimilarity(a, b time.Duration) float64 {
+	const tolerance, limit = 2 * time.Second, 20 * time.Second
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d <= tolerance:
+		return 1
+	case d >= limit:
+		return 0
+	}
+	return float64(limit-d) / float64(limit-tolerance)
+}
+
+func stripParenthesized(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth > 0 {
+				depth--
+			}
+		default:
+			if depth == 0 {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// words returns the set of the lower-case words of s, with & read as and.
+func words(s string) map[string]bool {
+	s = strings.Replace(strings.ToLower(s), "&", " and ", -1)
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	return set
+}
@@ -0,0 +1,289 @@
+package applemusic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePlaylist_m3u8(t *testing.T) {
+	data := `#EXTM3U
+#PLAYLIST:Road Trip
+#EXTINF:280,U2 - Sunday Bloody Sunday
+https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098
+#EXTINF:-1,Age of Consent
+/v1/me/library/songs/i.2
+
+Music/New Order/Blue Monday.mp3
+`
+	title, entries, err := ParsePlaylist(strings.NewReader(data), ExportFormatM3U8)
+	if err != nil {
+		t.Fatalf("ParsePlaylist returned error: %v", err)
+	}
+
+	want := []PlaylistEntry{
+		{
+			Title:      "Sunday Bloody Sunday",
+			ArtistName: "U2",
+			Duration:   280 * time.Second,
+			CatalogId:  "1440833098",
+			Location:   "https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098",
+		},
+		{Title: "Age of Consent", Location: "/v1/me/library/songs/i.2"},
+		{Title: "Blue Monday", Location: "Music/New Order/Blue Monday.mp3"},
+	}
+	if title != "Road Trip" || !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParsePlaylist = %q, %+v, want %q, %+v", title, entries, "Road Trip", want)
+	}
+}
+
+func TestParsePlaylist_csv(t *testing.T) {
+	data := `playlist,id,catalog_id,name,artist_name,album_name,disc_number,track_number,duration_ms
+,i.2,,Age of Consent,New Order,"Power, Corruption & Lies",0,0,315000
+Road Trip,1440833098,1440833098,Sunday Bloody Sunday,U2,,0,0,280400
+`
+	_, entries, err := ParsePlaylist(strings.NewReader(data), ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("ParsePlaylist returned error: %v", err)
+	}
+
+	want := []PlaylistEntry{
+		{Title: "Age of Consent", ArtistName: "New Order", AlbumName: "Power, Corruption & Lies", Duration: 315 * time.Second},
+		{Title: "Sunday Bloody Sunday", ArtistName: "U2", CatalogId: "1440833098", Duration: 280400 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParsePlaylist = %+v, want %+v", entries, want)
+	}
+
+	if _, _, err := ParsePlaylist(strings.NewReader("artist\nU2\n"), ExportFormatCSV); err == nil {
+		t.Errorf("ParsePlaylist of a CSV file without title returned no error")
+	}
+}
+
+func TestParsePlaylist_xspf(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>Road Trip</title>
+  <trackList>
+    <track>
+      <location>https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098</location>
+      <identifier>https://music.apple.com/song/1440833098</identifier>
+      <title>Sunday Bloody Sunday</title>
+      <creator>U2</creator>
+      <duration>280400</duration>
+    </track>
+    <track>
+      <identifier>isrc:GBAAN0100001</identifier>
+      <title>Age of Consent</title>
+      <creator>New Order</creator>
+      <album>Power, Corruption &amp; Lies</album>
+    </track>
+  </trackList>
+</playlist>
+`
+	title, entries, err := ParsePlaylist(strings.NewReader(data), ExportFormatXSPF)
+	if err != nil {
+		t.Fatalf("ParsePlaylist returned error: %v", err)
+	}
+
+	want := []PlaylistEntry{
+		{
+			Title:      "Sunday Bloody Sunday",
+			ArtistName: "U2",
+			Duration:   280400 * time.Millisecond,
+			CatalogId:  "1440833098",
+			Location:   "https://music.apple.com/us/album/sunday-bloody-sunday/1440833083?i=1440833098",
+		},
+		{Title: "Age of Consent", ArtistName: "New Order", AlbumName: "Power, Corruption & Lies", ISRC: "GBAAN0100001"},
+	}
+	if title != "Road Trip" || !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParsePlaylist = %q, %+v, want %q, %+v", title, entries, "Road Trip", want)
+	}
+}
+
+func TestParsePlaylist_invalidFormat(t *testing.T) {
+	if _, _, err := ParsePlaylist(strings.NewReader(""), ExportFormatJSON); !errors.Is(err, ErrBadParameter) {
+		t.Errorf("ParsePlaylist returned error %v, want ErrBadParameter", err)
+	}
+}
+
+const importPlaylistCSV = `name,artist_name,album_name,isrc,catalog_id,duration_ms
+Sunday Bloody Sunday,U2,War,,1440833098,280400
+Age of Consent,New Order,"Power, Corruption & Lies",GBAAN0100001,,
+Blue Monday,New Order,,,,449000
+Heroes,David Bowie,,,,
+Unknown Song,Nobody,,,,
+`
+
+func setupImport(t *testing.T) {
+	mux.HandleFunc("/v1/catalog/us/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.FormValue("filter[isrc]") != "" {
+			testFormValues(t, r, values{"filter[isrc]": "GBAAN0100001"})
+			fmt.Fprint(w, `{"data": [
+    {"id": "2", "type": "songs", "attributes": {"name": "Age of Consent", "artistName": "New Order", "isrc": "GBAAN0100001", "albumName": "Singles"}},
+    {"id": "3", "type": "songs", "attributes": {"name": "Age of Consent", "artistName": "New Order", "isrc": "GBAAN0100001", "albumName": "Power, Corruption & Lies"}}
+]}`)
+			return
+		}
+		testFormValues(t, r, values{"ids": "1440833098"})
+		fmt.Fprint(w, `{"data": [{"id": "1440833098", "type": "songs", "attributes": {"name": "Sunday Bloody Sunday", "artistName": "U2"}}]}`)
+	})
+	mux.HandleFunc("/v1/catalog/us/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch term := r.FormValue("term"); term {
+		case "Blue Monday New Order":
+			testFormValues(t, r, values{"term": term, "types": "songs", "limit": "10"})
+			fmt.Fprint(w, `{"results": {"songs": {"data": [
+    {"id": "4", "type": "songs", "attributes": {"name": "Blue Monday '88", "artistName": "New Order", "isrc": "GBAAN8800001", "durationInMillis": 249000}},
+    {"id": "5", "type": "songs", "attributes": {"name": "Blue Monday", "artistName": "New Order", "isrc": "GBAAN8300001", "durationInMillis": 448000}}
+]}}}`)
+		case "Heroes David Bowie":
+			fmt.Fprint(w, `{"results": {"songs": {"data": [
+    {"id": "6", "type": "songs", "attributes": {"name": "\"Heroes\"", "artistName": "David Bowie", "isrc": "USJT19900001"}},
+    {"id": "7", "type": "songs", "attributes": {"name": "Heroes (Live)", "artistName": "David Bowie", "isrc": "USJT19900002"}}
+]}}}`)
+		default:
+			fmt.Fprint(w, `{"results": {}}`)
+		}
+	})
+}
+
+func TestClient_ImportPlaylist(t *testing.T) {
+	setup()
+	defer teardown()
+	setupImport(t)
+
+	var created []string
+	mux.HandleFunc("/v1/me/library/playlists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testJsonBodyValues(t, r, []byte(`{
+    "attributes": {"name": "Road Trip", "description": "Imported"},
+    "relationships": {"tracks": {"data": [
+        {"id": "1440833098", "type": "songs"},
+        {"id": "3", "type": "songs"},
+        {"id": "5", "type": "songs"}
+    ]}}
+}`))
+		created = append(created, "p.1")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data": [{"id": "p.1", "type": "library-playlists", "attributes": {"name": "Road Trip"}}]}`)
+	})
+
+	opt := &ImportOptions{Format: ExportFormatCSV, Name: "Road Trip", Description: "Imported"}
+	report, err := client.ImportPlaylist(context.Background(), "us", strings.NewReader(importPlaylistCSV), opt)
+	if err != nil {
+		t.Fatalf("ImportPlaylist returned error: %v", err)
+	}
+
+	if len(created) != 1 || report.Playlist == nil || report.Playlist.Id != "p.1" {
+		t.Errorf("ImportPlaylist created %v, playlist %+v, want p.1", created, report.Playlist)
+	}
+
+	var got []string
+	for _, result := range report.Results {
+		id := ""
+		if result.Song != nil {
+			id = result.Song.Id
+		}
+		got = append(got, fmt.Sprintf("%s %s %d", result.Entry.Title, id, len(result.Candidates)))
+	}
+	want := []string{
+		"Sunday Bloody Sunday 1440833098 0",
+		"Age of Consent 3 0",
+		"Blue Monday 5 0",
+		"Heroes 6 2",
+		"Unknown Song  0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportPlaylist results = %q, want %q", got, want)
+	}
+	if n, a, u := len(report.Matched()), len(report.Ambiguous()), len(report.Unmatched()); n != 3 || a != 1 || u != 1 {
+		t.Errorf("ImportPlaylist matched %d, ambiguous %d, unmatched %d, want 3, 1 and 1", n, a, u)
+	}
+}
+
+func TestClient_ImportPlaylist_dryRun(t *testing.T) {
+	setup()
+	defer teardown()
+	setupImport(t)
+
+	mux.HandleFunc("/v1/me/library/playlists", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("ImportPlaylist created a playlist in a dry run")
+	})
+
+	opt := &ImportOptions{Format: ExportFormatCSV, DryRun: true}
+	report, err := client.ImportPlaylist(context.Background(), "us", strings.NewReader(importPlaylistCSV), opt)
+	if err != nil {
+		t.Fatalf("ImportPlaylist returned error: %v", err)
+	}
+	if report.Playlist != nil || len(report.Results) != 5 {
+		t.Errorf("ImportPlaylist = %+v, want 5 results and no playlist", report)
+	}
+}
+
+func TestClient_ImportPlaylist_manyTracks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var ids []string
+	var m3u strings.Builder
+	m3u.WriteString("#EXTM3U\n#PLAYLIST:Long\n")
+	for i := 1; i <= 250; i++ {
+		ids = append(ids, fmt.Sprint(i))
+		fmt.Fprintf(&m3u, "https://music.apple.com/us/song/%d\n", i)
+	}
+
+	mux.HandleFunc("/v1/catalog/us/songs", func(w http.ResponseWriter, r *http.Request) {
+		var songs Songs
+		for _, id := range strings.Split(r.FormValue("ids"), ",") {
+			songs.Data = append(songs.Data, Song{Id: id, Type: "songs"})
+		}
+		_ = json.NewEncoder(w).Encode(songs)
+	})
+
+	var added []int
+	mux.HandleFunc("/v1/me/library/playlists", func(w http.ResponseWriter, r *http.Request) {
+		var body CreateLibraryPlaylist
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		added = append(added, len(body.Relationships.Tracks.Data))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data": [{"id": "p.1", "type": "library-playlists", "attributes": {"name": "Long"}}]}`)
+	})
+	mux.HandleFunc("/v1/me/library/playlists/p.1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := ioutil.ReadAll(r.Body)
+		var data CreateLibraryPlaylistTrackData
+		_ = json.Unmarshal(body, &data)
+		added = append(added, len(data.Data))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	report, err := client.ImportPlaylist(context.Background(), "us", strings.NewReader(m3u.String()), &ImportOptions{Format: ExportFormatM3U8})
+	if err != nil {
+		t.Fatalf("ImportPlaylist returned error: %v", err)
+	}
+	if report.Name != "Long" || len(report.Matched()) != 250 {
+		t.Errorf("ImportPlaylist = %q with %d matched entries, want Long with 250", report.Name, len(report.Matched()))
+	}
+	if want := []int{100, 100, 50}; !reflect.DeepEqual(added, want) {
+		t.Errorf("ImportPlaylist added tracks by %v, want %v", added, want)
+	}
+}
+
+func TestClient_ImportPlaylist_noName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.ImportPlaylist(context.Background(), "us", strings.NewReader(importPlaylistCSV), &ImportOptions{Format: ExportFormatCSV})
+	if !errors.Is(err, ErrBadParameter) {
+		t.Errorf("ImportPlaylist returned error %v, want ErrBadParameter", err)
+	}
+}
@@ -11,6 +11,46 @@ type HistoryHeavyRotation struct {
 	Next string     `json:"next,omitempty"`
 }
 
+// Parse parses the resources in heavy rotation with Resource.Parse.
+func (h HistoryHeavyRotation) Parse() ([]interface{}, error) {
+	return parseAll(h.Data)
+}
+
+// Albums returns the albums in heavy rotation.
+func (h HistoryHeavyRotation) Albums() ([]Album, error) {
+	var albums []Album
+	err := parseResources(h.Data, "albums", &albums)
+	return albums, err
+}
+
+// Playlists returns the playlists in heavy rotation.
+func (h HistoryHeavyRotation) Playlists() ([]Playlist, error) {
+	var playlists []Playlist
+	err := parseResources(h.Data, "playlists", &playlists)
+	return playlists, err
+}
+
+// Stations returns the stations in heavy rotation.
+func (h HistoryHeavyRotation) Stations() ([]Station, error) {
+	var stations []Station
+	err := parseResources(h.Data, "stations", &stations)
+	return stations, err
+}
+
+// LibraryAlbums returns the library albums in heavy rotation.
+func (h HistoryHeavyRotation) LibraryAlbums() ([]LibraryAlbum, error) {
+	var libraryAlbums []LibraryAlbum
+	err := parseResources(h.Data, "library-albums", &libraryAlbums)
+	return libraryAlbums, err
+}
+
+// LibraryPlaylists returns the library playlists in heavy rotation.
+func (h HistoryHeavyRotation) LibraryPlaylists() ([]LibraryPlaylist, error) {
+	var libraryPlaylists []LibraryPlaylist
+	err := parseResources(h.Data, "library-playlists", &libraryPlaylists)
+	return libraryPlaylists, err
+}
+
 // GetHistoryHeavyRotation fetches the resources in heavy rotation for the user.
 func (s *MeService) GetHistoryHeavyRotation(ctx context.Context, opt *PageOptions) (*HistoryHeavyRotation, *Response, error) {
 	u := "v1/me/history/heavy-rotation"
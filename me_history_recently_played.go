@@ -0,0 +1,60 @@
+package applemusic
+
+import "context"
+
+// HistoryRecentlyPlayed represents a list of recently played resources,
+// such as albums, playlists, stations, library albums and library playlists.
+type HistoryRecentlyPlayed struct {
+	Data []Resource `json:"data"`
+	Href string     `json:"href,omitempty"`
+	Next string     `json:"next,omitempty"`
+}
+
+// Parse parses the recently played resources with Resource.Parse.
+func (h HistoryRecentlyPlayed) Parse() ([]interface{}, error) {
+	return parseAll(h.Data)
+}
+
+// GetHistoryRecentlyPlayed fetches the recently played resources for the user.
+func (s *MeService) GetHistoryRecentlyPlayed(ctx context.Context, opt *PageOptions) (*HistoryRecentlyPlayed, *Response, error) {
+	u := "v1/me/recent/played"
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recentlyPlayed := &HistoryRecentlyPlayed{}
+	resp, err := s.client.Do(ctx, req, recentlyPlayed)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return recentlyPlayed, resp, nil
+}
+
+// GetHistoryRecentStations fetches the recently played radio stations for the user.
+func (s *MeService) GetHistoryRecentStations(ctx context.Context, opt *PageOptions) (*Stations, *Response, error) {
+	u := "v1/me/recent/radio-stations"
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stations := &Stations{}
+	resp, err := s.client.Do(ctx, req, stations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return stations, resp, nil
+}
@@ -0,0 +1,121 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMeService_GetHistoryRecentlyPlayed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/recent/played", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit": "2",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+  "data": [
+    {
+      "attributes": {
+        "name": "The Essential Celine Dion"
+      },
+      "href": "/v1/catalog/us/albums/464056948",
+      "id": "464056948",
+      "type": "albums"
+    },
+    {
+      "attributes": {
+        "name": "Car Songs"
+      },
+      "href": "/v1/me/library/playlists/p.MoGJYM3CYXW09B",
+      "id": "p.MoGJYM3CYXW09B",
+      "type": "library-playlists"
+    }
+  ],
+  "href": "/v1/me/recent/played?limit=2",
+  "next": "/v1/me/recent/played?offset=2"
+}`))
+	})
+
+	got, _, err := client.Me.GetHistoryRecentlyPlayed(context.Background(), &PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Me.GetHistoryRecentlyPlayed returned error: %v", err)
+	}
+	if want := "/v1/me/recent/played?offset=2"; got.Next != want {
+		t.Errorf("Me.GetHistoryRecentlyPlayed next = %v, want %v", got.Next, want)
+	}
+
+	parsed, err := got.Parse()
+	if err != nil {
+		t.Fatalf("HistoryRecentlyPlayed.Parse returned error: %v", err)
+	}
+	want := []interface{}{
+		&Album{
+			Id:         "464056948",
+			Type:       "albums",
+			Href:       "/v1/catalog/us/albums/464056948",
+			Attributes: AlbumAttributes{Name: "The Essential Celine Dion"},
+		},
+		&LibraryPlaylist{
+			Id:         "p.MoGJYM3CYXW09B",
+			Type:       "library-playlists",
+			Href:       "/v1/me/library/playlists/p.MoGJYM3CYXW09B",
+			Attributes: LibraryPlaylistAttributes{Name: "Car Songs"},
+		},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("HistoryRecentlyPlayed.Parse = %+v, want %+v", parsed, want)
+	}
+}
+
+func TestMeService_GetHistoryRecentStations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/recent/radio-stations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+  "data": [
+    {
+      "attributes": {
+        "isLive": true,
+        "name": "Beats 1"
+      },
+      "href": "/v1/catalog/us/stations/ra.978194965",
+      "id": "ra.978194965",
+      "type": "stations"
+    }
+  ],
+  "href": "/v1/me/recent/radio-stations"
+}`))
+	})
+
+	got, _, err := client.Me.GetHistoryRecentStations(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Me.GetHistoryRecentStations returned error: %v", err)
+	}
+	want := &Stations{
+		Data: []Station{
+			{
+				Id:   "ra.978194965",
+				Type: "stations",
+				Href: "/v1/catalog/us/stations/ra.978194965",
+				Attributes: StationAttributes{
+					IsLive: true,
+					Name:   "Beats 1",
+				},
+			},
+		},
+		Href: "/v1/me/recent/radio-stations",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetHistoryRecentStations = %+v, want %+v", got, want)
+	}
+}
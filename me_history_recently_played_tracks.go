@@ -8,12 +8,12 @@ type HistoryRecentlyPlayedTrackAttributes struct {
 	ArtistName           string         `json:"artistName"`
 	Artwork              Artwork        `json:"artwork"`
 	DiscNumber           int            `json:"discNumber"`
-	DurationInMillis     int64          `json:"durationInMillis,omitempty"`
+	DurationInMillis     Milliseconds   `json:"durationInMillis,omitempty"`
 	Name                 string         `json:"name"`
 	PlayParams           PlayParameters `json:"playParams,omitempty"`
 	TrackNumber          int            `json:"trackNumber"`
 	GenreNames           []string       `json:"genreNames"`
-	ReleaseDate          string         `json:"releaseDate"`
+	ReleaseDate          Date           `json:"releaseDate"`
 	ISRC                 string         `json:"isrc"`
 	URL                  string         `json:"url"`
 	HasLyrics            bool           `json:"hasLyrics"`
@@ -1,5 +1,7 @@
 package applemusic
 
+import "context"
+
 // LibraryPlaylistAttributes represents the attributes of library playlist.
 type LibraryPlaylistAttributes struct {
 	Artwork     *Artwork        `json:"artwork"`
@@ -24,3 +26,43 @@ type LibraryPlaylist struct {
 	Attributes    LibraryPlaylistAttributes    `json:"attributes"`
 	Relationships LibraryPlaylistRelationships `json:"relationships"`
 }
+
+// AddToLibraryOptions specifies the catalog resources to add to the library, by type.
+type AddToLibraryOptions struct {
+	Albums      []string `url:"ids[albums],comma,omitempty"`
+	MusicVideos []string `url:"ids[music-videos],comma,omitempty"`
+	Playlists   []string `url:"ids[playlists],comma,omitempty"`
+	Songs       []string `url:"ids[songs],comma,omitempty"`
+}
+
+// AddToLibrary adds one or more catalog resources to the user’s library.
+func (s *MeService) AddToLibrary(ctx context.Context, opt *AddToLibraryOptions) (*Response, error) {
+	u := "v1/me/library"
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// getLibraryRelationship fetches the related resources of a library resource into v,
+// such as the catalog resource of a library song.
+func (s *MeService) getLibraryRelationship(ctx context.Context, u string, opt interface{}, v interface{}) (*Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, v)
+}
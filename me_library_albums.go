@@ -1,6 +1,9 @@
 package applemusic
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // LibraryAlbumAttributes represents the attributes of library album object.
 type LibraryAlbumAttributes struct {
@@ -10,12 +13,14 @@ type LibraryAlbumAttributes struct {
 	GenreNames []string       `json:"genreNames"`
 	Artwork    Artwork        `json:"artwork"`
 	PlayParams PlayParameters `json:"playParams,omitempty"`
-	DateAdded  string         `json:"dateAdded"`
+	DateAdded  Date           `json:"dateAdded"`
 }
 
+// LibraryAlbumRelationships represents a to-one or to-many relationship from one resource object to others.
 type LibraryAlbumRelationships struct {
 	Catalog Albums  `json:"catalog"`
 	Artists Artists `json:"artists"`
+	Tracks  *Tracks `json:"tracks,omitempty"` // The library songs and library music videos on the album.
 }
 
 // LibraryAlbum represents a Resource object that represents a library album.
@@ -60,3 +65,57 @@ func (s *MeService) GetAllLibraryAlbums(ctx context.Context, opt *PageOptions) (
 
 	return s.getLibraryAlbums(ctx, u, opt)
 }
+
+// GetLibraryAlbum fetches a library album using its identifier.
+func (s *MeService) GetLibraryAlbum(ctx context.Context, id string, opt *Options) (*LibraryAlbums, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/albums/%s", id)
+
+	return s.getLibraryAlbums(ctx, u, opt)
+}
+
+// GetLibraryAlbumsByIds fetches one or more library albums using their identifiers.
+func (s *MeService) GetLibraryAlbumsByIds(ctx context.Context, ids []string, opt *Options) (*LibraryAlbums, *Response, error) {
+	u := "v1/me/library/albums"
+	libraryAlbums := &LibraryAlbums{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, libraryAlbums, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return libraryAlbums, resp, nil
+}
+
+// GetLibraryAlbumTracks fetches the library songs and library music videos of a library album using its identifier.
+func (s *MeService) GetLibraryAlbumTracks(ctx context.Context, id string, opt *PageOptions) (*Tracks, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/albums/%s/tracks", id)
+
+	tracks := &Tracks{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, tracks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tracks, resp, nil
+}
+
+// GetLibraryAlbumArtists fetches the library artists of a library album using its identifier.
+func (s *MeService) GetLibraryAlbumArtists(ctx context.Context, id string, opt *PageOptions) (*LibraryArtists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/albums/%s/artists", id)
+
+	return s.getLibraryArtists(ctx, u, opt)
+}
+
+// GetLibraryAlbumCatalog fetches the catalog album of a library album using its identifier.
+func (s *MeService) GetLibraryAlbumCatalog(ctx context.Context, id string, opt *Options) (*Albums, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/albums/%s/catalog", id)
+
+	albums := &Albums{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, albums)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return albums, resp, nil
+}
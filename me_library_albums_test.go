@@ -182,3 +182,129 @@ var libraryAlbums = &LibraryAlbums{
 	},
 	Next: "/v1/me/library/albums?offset=25",
 }
+
+func TestMeService_GetLibraryAlbum(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/albums/l.jyBdqAH", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"l.jyBdqAH","type":"library-albums","attributes":{"name":"War","trackCount":10}}]}`))
+	})
+
+	got, _, err := client.Me.GetLibraryAlbum(context.Background(), "l.jyBdqAH", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryAlbum returned error: %v", err)
+	}
+	want := &LibraryAlbums{
+		Data: []LibraryAlbum{
+			{Id: "l.jyBdqAH", Type: "library-albums", Attributes: LibraryAlbumAttributes{Name: "War", TrackCount: 10}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryAlbum = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibraryAlbumsByIds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids": "l.jyBdqAH,l.DDD6dwm",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"l.jyBdqAH","type":"library-albums"},{"id":"l.DDD6dwm","type":"library-albums"}]}`))
+	})
+
+	ids := []string{"l.jyBdqAH", "l.DDD6dwm"}
+	got, _, err := client.Me.GetLibraryAlbumsByIds(context.Background(), ids, nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryAlbumsByIds returned error: %v", err)
+	}
+	if missing := MissingIds(ids, got); missing != nil {
+		t.Errorf("Me.GetLibraryAlbumsByIds is missing %v", missing)
+	}
+}
+
+func TestMeService_GetLibraryAlbumTracks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/albums/l.jyBdqAH/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit": "2",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+    "data": [
+        {"id": "i.vMXdDeVhKQWRAd", "type": "library-songs", "attributes": {"name": "\"40\""}},
+        {"id": "i.PmDkr2ptb9VQ", "type": "library-music-videos", "attributes": {"name": "Sunday Bloody Sunday"}}
+    ],
+    "next": "/v1/me/library/albums/l.jyBdqAH/tracks?offset=2"
+}`))
+	})
+
+	got, _, err := client.Me.GetLibraryAlbumTracks(context.Background(), "l.jyBdqAH", &PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Me.GetLibraryAlbumTracks returned error: %v", err)
+	}
+	if want := "/v1/me/library/albums/l.jyBdqAH/tracks?offset=2"; got.Next != want {
+		t.Errorf("Me.GetLibraryAlbumTracks next = %v, want %v", got.Next, want)
+	}
+
+	songs, err := got.LibrarySongs()
+	if err != nil {
+		t.Errorf("Tracks.LibrarySongs returned error: %v", err)
+	}
+	if want := []LibrarySong{{Id: "i.vMXdDeVhKQWRAd", Type: "library-songs", Attributes: LibrarySongAttributes{Name: "\"40\""}}}; !reflect.DeepEqual(songs, want) {
+		t.Errorf("Tracks.LibrarySongs = %+v, want %+v", songs, want)
+	}
+	musicVideos, err := got.LibraryMusicVideos()
+	if err != nil {
+		t.Errorf("Tracks.LibraryMusicVideos returned error: %v", err)
+	}
+	if len(musicVideos) != 1 || musicVideos[0].Id != "i.PmDkr2ptb9VQ" {
+		t.Errorf("Tracks.LibraryMusicVideos = %+v, want one music video", musicVideos)
+	}
+}
+
+func TestMeService_GetLibraryAlbumCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/albums/l.jyBdqAH/catalog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"include": "tracks",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"1440881323","type":"albums","href":"/v1/catalog/us/albums/1440881323","attributes":{"name":"War"}}]}`))
+	})
+
+	got, _, err := client.Me.GetLibraryAlbumCatalog(context.Background(), "l.jyBdqAH", &Options{Include: []Relationship{RelationshipTracks}})
+	if err != nil {
+		t.Errorf("Me.GetLibraryAlbumCatalog returned error: %v", err)
+	}
+	want := &Albums{
+		Data: []Album{
+			{
+				Id:         "1440881323",
+				Type:       "albums",
+				Href:       "/v1/catalog/us/albums/1440881323",
+				Attributes: AlbumAttributes{Name: "War"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryAlbumCatalog = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+)
+
+// LibraryArtistAttributes represents the attributes of library artist object.
+type LibraryArtistAttributes struct {
+	Name string `json:"name"`
+}
+
+// LibraryArtistRelationships represents a to-one or to-many relationship from one resource object to others.
+type LibraryArtistRelationships struct {
+	Albums  *LibraryAlbums `json:"albums,omitempty"`  // The library albums of the artist. Default inclusion: None
+	Catalog *Artists       `json:"catalog,omitempty"` // The catalog artist of the library artist. Default inclusion: None
+}
+
+// LibraryArtist represents a Resource object that represents a library artist.
+type LibraryArtist struct {
+	Id            string                     `json:"id"`
+	Type          string                     `json:"type"`
+	Href          string                     `json:"href,omitempty"`
+	Attributes    LibraryArtistAttributes    `json:"attributes,omitempty"`
+	Relationships LibraryArtistRelationships `json:"relationships,omitempty"`
+}
+
+// LibraryArtists represents a list of library artists.
+type LibraryArtists struct {
+	Data []LibraryArtist `json:"data"`
+	Href string          `json:"href,omitempty"`
+	Next string          `json:"next,omitempty"`
+}
+
+func (s *MeService) getLibraryArtists(ctx context.Context, u string, opt interface{}) (*LibraryArtists, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	libraryArtists := &LibraryArtists{}
+	resp, err := s.client.Do(ctx, req, libraryArtists)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return libraryArtists, resp, nil
+}
+
+// GetLibraryArtist fetches a library artist using its identifier.
+func (s *MeService) GetLibraryArtist(ctx context.Context, id string, opt *Options) (*LibraryArtists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/artists/%s", id)
+
+	return s.getLibraryArtists(ctx, u, opt)
+}
+
+// GetLibraryArtistsByIds fetches one or more library artists using their identifiers.
+func (s *MeService) GetLibraryArtistsByIds(ctx context.Context, ids []string, opt *Options) (*LibraryArtists, *Response, error) {
+	u := "v1/me/library/artists"
+	libraryArtists := &LibraryArtists{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, libraryArtists, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return libraryArtists, resp, nil
+}
+
+// GetAllLibraryArtists fetches all the library artists in alphabetical order.
+func (s *MeService) GetAllLibraryArtists(ctx context.Context, opt *PageOptions) (*LibraryArtists, *Response, error) {
+	u := "v1/me/library/artists"
+
+	return s.getLibraryArtists(ctx, u, opt)
+}
+
+// GetLibraryArtistAlbums fetches the library albums of a library artist using its identifier.
+func (s *MeService) GetLibraryArtistAlbums(ctx context.Context, id string, opt *PageOptions) (*LibraryAlbums, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/artists/%s/albums", id)
+
+	return s.getLibraryAlbums(ctx, u, opt)
+}
+
+// GetLibraryArtistCatalog fetches the catalog artist of a library artist using its identifier.
+func (s *MeService) GetLibraryArtistCatalog(ctx context.Context, id string, opt *Options) (*Artists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/artists/%s/catalog", id)
+
+	artists := &Artists{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, artists)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return artists, resp, nil
+}
@@ -0,0 +1,250 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMeService_GetLibraryArtist(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/artists/r.2N8Fv0k", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"include": "albums,catalog",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(libraryArtistsJSON)
+	})
+
+	got, _, err := client.Me.GetLibraryArtist(context.Background(), "r.2N8Fv0k", &Options{Include: []Relationship{RelationshipAlbums, RelationshipCatalog}})
+	if err != nil {
+		t.Errorf("Me.GetLibraryArtist returned error: %v", err)
+	}
+	if want := libraryArtists; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryArtist = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibraryArtistsByIds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/artists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids":     "r.2N8Fv0k,r.u7AyKin",
+			"include": "albums,catalog",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(libraryArtistsJSON)
+	})
+
+	got, _, err := client.Me.GetLibraryArtistsByIds(context.Background(), []string{"r.2N8Fv0k", "r.u7AyKin"}, &Options{Include: []Relationship{RelationshipAlbums, RelationshipCatalog}})
+	if err != nil {
+		t.Errorf("Me.GetLibraryArtistsByIds returned error: %v", err)
+	}
+	if want := libraryArtists; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryArtistsByIds = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetAllLibraryArtists(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/artists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit":  "1",
+			"offset": "1",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(libraryArtistsJSON)
+	})
+
+	got, _, err := client.Me.GetAllLibraryArtists(context.Background(), &PageOptions{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Errorf("Me.GetAllLibraryArtists returned error: %v", err)
+	}
+	if want := libraryArtists; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetAllLibraryArtists = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibraryArtistAlbums(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/artists/r.2N8Fv0k/albums", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit": "1",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+    "data": [
+        {
+            "attributes": {
+                "artistName": "U2",
+                "name": "War",
+                "trackCount": 10
+            },
+            "href": "/v1/me/library/albums/l.jyBdqAH",
+            "id": "l.jyBdqAH",
+            "type": "library-albums"
+        }
+    ],
+    "href": "/v1/me/library/artists/r.2N8Fv0k/albums?limit=1"
+}`))
+	})
+
+	got, _, err := client.Me.GetLibraryArtistAlbums(context.Background(), "r.2N8Fv0k", &PageOptions{Limit: 1})
+	if err != nil {
+		t.Errorf("Me.GetLibraryArtistAlbums returned error: %v", err)
+	}
+	want := &LibraryAlbums{
+		Data: []LibraryAlbum{
+			{
+				Id:   "l.jyBdqAH",
+				Type: "library-albums",
+				Href: "/v1/me/library/albums/l.jyBdqAH",
+				Attributes: LibraryAlbumAttributes{
+					ArtistName: "U2",
+					Name:       "War",
+					TrackCount: 10,
+				},
+			},
+		},
+		Href: "/v1/me/library/artists/r.2N8Fv0k/albums?limit=1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryArtistAlbums = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibraryArtistCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/artists/r.2N8Fv0k/catalog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+    "data": [
+        {
+            "attributes": {
+                "genreNames": [
+                    "Rock"
+                ],
+                "name": "U2",
+                "url": "https://music.apple.com/us/artist/u2/78500"
+            },
+            "href": "/v1/catalog/us/artists/78500",
+            "id": "78500",
+            "type": "artists"
+        }
+    ]
+}`))
+	})
+
+	got, _, err := client.Me.GetLibraryArtistCatalog(context.Background(), "r.2N8Fv0k", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryArtistCatalog returned error: %v", err)
+	}
+	want := &Artists{
+		Data: []Artist{
+			{
+				Id:   "78500",
+				Type: "artists",
+				Href: "/v1/catalog/us/artists/78500",
+				Attributes: ArtistAttributes{
+					GenreNames: []string{"Rock"},
+					Name:       "U2",
+					URL:        "https://music.apple.com/us/artist/u2/78500",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryArtistCatalog = %+v, want %+v", got, want)
+	}
+}
+
+var libraryArtistsJSON = []byte(`{
+    "data": [
+        {
+            "attributes": {
+                "name": "U2"
+            },
+            "href": "/v1/me/library/artists/r.2N8Fv0k",
+            "id": "r.2N8Fv0k",
+            "relationships": {
+                "albums": {
+                    "data": [
+                        {
+                            "href": "/v1/me/library/albums/l.jyBdqAH",
+                            "id": "l.jyBdqAH",
+                            "type": "library-albums"
+                        }
+                    ],
+                    "href": "/v1/me/library/artists/r.2N8Fv0k/albums"
+                },
+                "catalog": {
+                    "data": [
+                        {
+                            "href": "/v1/catalog/us/artists/78500",
+                            "id": "78500",
+                            "type": "artists"
+                        }
+                    ],
+                    "href": "/v1/me/library/artists/r.2N8Fv0k/catalog"
+                }
+            },
+            "type": "library-artists"
+        }
+    ]
+}`)
+
+var libraryArtists = &LibraryArtists{
+	Data: []LibraryArtist{
+		{
+			Id:   "r.2N8Fv0k",
+			Type: "library-artists",
+			Href: "/v1/me/library/artists/r.2N8Fv0k",
+			Attributes: LibraryArtistAttributes{
+				Name: "U2",
+			},
+			Relationships: LibraryArtistRelationships{
+				Albums: &LibraryAlbums{
+					Data: []LibraryAlbum{
+						{
+							Id:   "l.jyBdqAH",
+							Type: "library-albums",
+							Href: "/v1/me/library/albums/l.jyBdqAH",
+						},
+					},
+					Href: "/v1/me/library/artists/r.2N8Fv0k/albums",
+				},
+				Catalog: &Artists{
+					Data: []Artist{
+						{
+							Id:   "78500",
+							Type: "artists",
+							Href: "/v1/catalog/us/artists/78500",
+						},
+					},
+					Href: "/v1/me/library/artists/r.2N8Fv0k/catalog",
+				},
+			},
+		},
+	},
+}
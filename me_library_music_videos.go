@@ -1,6 +1,9 @@
 package applemusic
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // LibraryMusicVideoAttributes represents the attributes of library music video object.
 type LibraryMusicVideoAttributes struct {
@@ -8,18 +11,26 @@ type LibraryMusicVideoAttributes struct {
 	ArtistName       string         `json:"artistName"`
 	Artwork          Artwork        `json:"artwork"`
 	ContentRating    string         `json:"contentRating,omitempty"`
-	DurationInMillis int64          `json:"durationInMillis,omitempty"`
+	DurationInMillis Milliseconds   `json:"durationInMillis,omitempty"`
 	Name             string         `json:"name"`
 	PlayParams       PlayParameters `json:"playParams,omitempty"`
 	TrackNumber      int            `json:"trackNumber,omitempty"`
 }
 
+// LibraryMusicVideoRelationships represents a to-one or to-many relationship from one resource object to others.
+type LibraryMusicVideoRelationships struct {
+	Albums  *LibraryAlbums  `json:"albums,omitempty"`  // The library albums of the music video. Default inclusion: None
+	Artists *LibraryArtists `json:"artists,omitempty"` // The library artists of the music video. Default inclusion: None
+	Catalog *MusicVideos    `json:"catalog,omitempty"` // The catalog music video of the library music video. Default inclusion: None
+}
+
 // LibraryMusicVideo represents a Resource object that represents a library music video.
 type LibraryMusicVideo struct {
-	Id         string                      `json:"id"`
-	Type       string                      `json:"type"`
-	Href       string                      `json:"href,omitempty"`
-	Attributes LibraryMusicVideoAttributes `json:"attributes,omitempty"`
+	Id            string                         `json:"id"`
+	Type          string                         `json:"type"`
+	Href          string                         `json:"href,omitempty"`
+	Attributes    LibraryMusicVideoAttributes    `json:"attributes,omitempty"`
+	Relationships LibraryMusicVideoRelationships `json:"relationships,omitempty"`
 }
 
 // LibraryMusicVideos represents a list of library music video.
@@ -55,3 +66,51 @@ func (s *MeService) GetAllLibraryMusicVideos(ctx context.Context, opt *PageOptio
 
 	return s.getLibraryMusicVideos(ctx, u, opt)
 }
+
+// GetLibraryMusicVideo fetches a library music video using its identifier.
+func (s *MeService) GetLibraryMusicVideo(ctx context.Context, id string, opt *Options) (*LibraryMusicVideos, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/music-videos/%s", id)
+
+	return s.getLibraryMusicVideos(ctx, u, opt)
+}
+
+// GetLibraryMusicVideosByIds fetches one or more library music videos using their identifiers.
+func (s *MeService) GetLibraryMusicVideosByIds(ctx context.Context, ids []string, opt *Options) (*LibraryMusicVideos, *Response, error) {
+	u := "v1/me/library/music-videos"
+	libraryMusicVideos := &LibraryMusicVideos{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, libraryMusicVideos, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return libraryMusicVideos, resp, nil
+}
+
+// GetLibraryMusicVideoAlbums fetches the library albums of a library music video using its identifier.
+func (s *MeService) GetLibraryMusicVideoAlbums(ctx context.Context, id string, opt *PageOptions) (*LibraryAlbums, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/music-videos/%s/albums", id)
+
+	return s.getLibraryAlbums(ctx, u, opt)
+}
+
+// GetLibraryMusicVideoArtists fetches the library artists of a library music video using its identifier.
+func (s *MeService) GetLibraryMusicVideoArtists(ctx context.Context, id string, opt *PageOptions) (*LibraryArtists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/music-videos/%s/artists", id)
+
+	return s.getLibraryArtists(ctx, u, opt)
+}
+
+// GetLibraryMusicVideoCatalog fetches the catalog music video of a library music video using its identifier.
+func (s *MeService) GetLibraryMusicVideoCatalog(ctx context.Context, id string, opt *Options) (*MusicVideos, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/music-videos/%s/catalog", id)
+
+	musicVideos := &MusicVideos{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, musicVideos)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return musicVideos, resp, nil
+}
@@ -109,27 +109,22 @@ func (s *MeService) getLibraryPlaylistsTracks(ctx context.Context, u string, opt
 	return libraryPlaylistTracks, resp, nil
 }
 
-// GetLibraryPlaylist fetches a library playlist using its identifier.
+// GetLibraryPlaylistTracks fetches the tracks of a library playlist using its identifier, following the next pages.
+// The opt.Limit, when set, caps the number of returned tracks.
 func (s *MeService) GetLibraryPlaylistTracks(ctx context.Context, id string, opt *PageOptions) ([]Song, int, error) {
 	u := fmt.Sprintf("v1/me/library/playlists/%s/tracks", id)
-	var tracks []Song
-	total := 0
-	limit := opt.Limit
-	for len(u) > 0 {
-		if limit > 0 && len(tracks) >= limit {
-			return tracks, total, nil
-		}
-		lpt, _, err := s.getLibraryPlaylistsTracks(ctx, u, opt)
-		if err != nil {
-			return tracks, total, err
-		}
-		total = lpt.Meta.Total
+	lpt, _, err := s.getLibraryPlaylistsTracks(ctx, u, opt)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		tracks = append(tracks, lpt.Data...)
-		u = lpt.Next
+	paginateOpt := &PaginateOptions{Options: opt}
+	if opt != nil {
+		paginateOpt.MaxItems = opt.Limit
 	}
+	_, err = s.client.CollectAll(ctx, lpt, paginateOpt)
 
-	return tracks, total, nil
+	return lpt.Data, lpt.Meta.Total, err
 }
 
 func (s *MeService) getLibraryPlaylistCatalogTracks(ctx context.Context, u string, opt interface{}) (*LibraryPlaylistTracks, *Response, error) {
@@ -158,35 +153,34 @@ func (s *MeService) getLibraryPlaylistCatalogTracks(ctx context.Context, u strin
 // GetLibraryPlaylistCatalogTracks fetches a library playlist using its identifier to get the catalog tracks of the playlist.
 func (s *MeService) GetLibraryPlaylistCatalogTracks(ctx context.Context, id string, limit int) ([]Song, error) {
 	u := fmt.Sprintf("v1/me/library/playlists/%s/catalog", id)
-	opt := &PageOptions{Limit: limit, Options: Options{Include: "tracks"}}
-
-	var tracks []Song
+	opt := &PageOptions{Limit: limit, Options: Options{Include: []Relationship{RelationshipTracks}}}
 
 	// first call is a different response then the pagination
 	lpt, _, err := s.getLibraryPlaylistCatalogTracks(ctx, u, opt)
 	if err != nil {
-		return tracks, err
+		return nil, err
+	}
+	if lpt == nil {
+		return nil, nil
 	}
-
-	tracks = append(tracks, lpt.Data...)
 
 	// get the rest of the songs if they exist
-	u = lpt.Next
-	for len(u) > 0 {
-		if limit != 0 && len(tracks) >= limit {
-			return tracks, nil
-		}
+	_, err = s.client.CollectAll(ctx, lpt, &PaginateOptions{MaxItems: limit, Options: opt})
+
+	return lpt.Data, err
+}
 
-		lpt, _, err := s.getLibraryPlaylistsTracks(ctx, u, opt)
-		if err != nil {
-			return tracks, err
-		}
+// GetLibraryPlaylistCatalog fetches the catalog playlist of a library playlist using its identifier.
+func (s *MeService) GetLibraryPlaylistCatalog(ctx context.Context, id string, opt *Options) (*Playlists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/playlists/%s/catalog", id)
 
-		tracks = append(tracks, lpt.Data...)
-		u = lpt.Next
+	playlists := &Playlists{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, playlists)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return tracks, nil
+	return playlists, resp, nil
 }
 
 type CreateLibraryPlaylistAttributes struct {
@@ -0,0 +1,38 @@
+package applemusic
+
+import "context"
+
+// LibraryRecentlyAdded represents a list of resources recently added to the library,
+// such as library albums, library artists and library playlists.
+type LibraryRecentlyAdded struct {
+	Data []Resource `json:"data"`
+	Href string     `json:"href,omitempty"`
+	Next string     `json:"next,omitempty"`
+}
+
+// Parse parses the recently added resources with Resource.Parse.
+func (l LibraryRecentlyAdded) Parse() ([]interface{}, error) {
+	return parseAll(l.Data)
+}
+
+// GetLibraryRecentlyAdded fetches the resources recently added to the library.
+func (s *MeService) GetLibraryRecentlyAdded(ctx context.Context, opt *PageOptions) (*LibraryRecentlyAdded, *Response, error) {
+	u := "v1/me/library/recently-added"
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recentlyAdded := &LibraryRecentlyAdded{}
+	resp, err := s.client.Do(ctx, req, recentlyAdded)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return recentlyAdded, resp, nil
+}
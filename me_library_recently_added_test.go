@@ -0,0 +1,67 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMeService_GetLibraryRecentlyAdded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/recently-added", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit":  "1",
+			"offset": "10",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+  "data": [
+    {
+      "attributes": {
+        "artistName": "U2",
+        "name": "War",
+        "trackCount": 10
+      },
+      "href": "/v1/me/library/albums/l.jyBdqAH",
+      "id": "l.jyBdqAH",
+      "type": "library-albums"
+    }
+  ],
+  "href": "/v1/me/library/recently-added?limit=1&offset=10",
+  "next": "/v1/me/library/recently-added?offset=11"
+}`))
+	})
+
+	got, _, err := client.Me.GetLibraryRecentlyAdded(context.Background(), &PageOptions{Limit: 1, Offset: 10})
+	if err != nil {
+		t.Fatalf("Me.GetLibraryRecentlyAdded returned error: %v", err)
+	}
+	if want := "/v1/me/library/recently-added?offset=11"; got.Next != want {
+		t.Errorf("Me.GetLibraryRecentlyAdded next = %v, want %v", got.Next, want)
+	}
+
+	parsed, err := got.Parse()
+	if err != nil {
+		t.Fatalf("LibraryRecentlyAdded.Parse returned error: %v", err)
+	}
+	want := []interface{}{
+		&LibraryAlbum{
+			Id:   "l.jyBdqAH",
+			Type: "library-albums",
+			Href: "/v1/me/library/albums/l.jyBdqAH",
+			Attributes: LibraryAlbumAttributes{
+				ArtistName: "U2",
+				Name:       "War",
+				TrackCount: 10,
+			},
+		},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("LibraryRecentlyAdded.Parse = %+v, want %+v", parsed, want)
+	}
+}
@@ -0,0 +1,79 @@
+package applemusic
+
+import "context"
+
+// LibrarySearchResults represents a results, that contains a map of library search results.
+// The members of the results object are the types of resources and the value for each is a Response Root object.
+type LibrarySearchResults struct {
+	LibraryAlbums      *LibraryAlbums      `json:"library-albums,omitempty"`
+	LibraryArtists     *LibraryArtists     `json:"library-artists,omitempty"`
+	LibraryMusicVideos *LibraryMusicVideos `json:"library-music-videos,omitempty"`
+	LibraryPlaylists   *LibraryPlaylists   `json:"library-playlists,omitempty"`
+	LibrarySongs       *LibrarySongs       `json:"library-songs,omitempty"`
+}
+
+// LibrarySearch represents the result of search for library resources.
+type LibrarySearch struct {
+	Results LibrarySearchResults `json:"results"`
+}
+
+// LibrarySearchOptions specifies the parameters to search the library.
+type LibrarySearchOptions struct {
+	// The entered text for the search (for example beatles abbey).
+	// The words are sent with ‘+’ characters between them, so they need not be inserted.
+	// A ‘+’ between two letters or digits, as in james+brown, still separates words,
+	// and the other ‘+’ characters, such as in C++, are encoded as %2B.
+	Term string `url:"term"`
+
+	// The list of the types of resources to include in the results.
+	// The possible values are library-albums, library-artists, library-music-videos, library-playlists and library-songs.
+	Types []ResourceType `url:"types,comma"`
+
+	// (Optional) The limit on the number of objects, or number of objects in the specified relationship, that are returned.
+	// The default value is 5 and the maximum value is 25.
+	Limit int `url:"limit,omitempty"`
+
+	// (Optional) The next page or group of objects to fetch.
+	Offset int `url:"offset,omitempty"`
+}
+
+func (opt *LibrarySearchOptions) validate() error {
+	if searchTerm(opt.Term) == "" {
+		return &InvalidOptionError{Option: "LibrarySearchOptions.Term", Reason: "must not be empty"}
+	}
+	if len(opt.Types) == 0 {
+		return &InvalidOptionError{Option: "LibrarySearchOptions.Types", Reason: "must not be empty"}
+	}
+	return validateLimit("LibrarySearchOptions.Limit", opt.Limit, maxSearchLimit)
+}
+
+// SearchLibrary searches the user’s library using a query.
+func (s *MeService) SearchLibrary(ctx context.Context, opt *LibrarySearchOptions) (*LibrarySearch, *Response, error) {
+	if opt == nil {
+		opt = &LibrarySearchOptions{}
+	}
+	if err := opt.validate(); err != nil {
+		return nil, nil, err
+	}
+	searchOpt := *opt
+	searchOpt.Term = searchTerm(opt.Term)
+
+	u := "v1/me/library/search"
+	u, err := addOptions(u, searchOpt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	librarySearch := &LibrarySearch{}
+	resp, err := s.client.Do(ctx, req, librarySearch)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return librarySearch, resp, nil
+}
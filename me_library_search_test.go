@@ -0,0 +1,164 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMeService_SearchLibrary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"term":  "u2",
+			"limit": "1",
+			"types": "library-songs,library-artists",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(librarySearchJSON)
+	})
+
+	opt := &LibrarySearchOptions{
+		Term:  "u2",
+		Limit: 1,
+		Types: []ResourceType{ResourceTypeLibrarySongs, ResourceTypeLibraryArtists},
+	}
+
+	got, _, err := client.Me.SearchLibrary(context.Background(), opt)
+	if err != nil {
+		t.Errorf("Me.SearchLibrary returned error: %v", err)
+	}
+	if want := librarySearch; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.SearchLibrary = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_SearchLibrary_offset(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"term":   "u2",
+			"limit":  "1",
+			"offset": "1",
+			"types":  "library-songs",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":{}}`))
+	})
+
+	opt := &LibrarySearchOptions{
+		Term:   "u2",
+		Limit:  1,
+		Offset: 1,
+		Types:  []ResourceType{ResourceTypeLibrarySongs},
+	}
+
+	got, _, err := client.Me.SearchLibrary(context.Background(), opt)
+	if err != nil {
+		t.Errorf("Me.SearchLibrary returned error: %v", err)
+	}
+	if want := (&LibrarySearch{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.SearchLibrary = %+v, want %+v", got, want)
+	}
+}
+
+var librarySearchJSON = []byte(`{
+    "results": {
+        "library-artists": {
+            "data": [
+                {
+                    "attributes": {
+                        "name": "U2"
+                    },
+                    "href": "/v1/me/library/artists/r.2N8Fv0k",
+                    "id": "r.2N8Fv0k",
+                    "type": "library-artists"
+                }
+            ],
+            "href": "/v1/me/library/search?limit=1&term=u2&types=library-artists",
+            "next": "/v1/me/library/search?offset=1&term=u2&types=library-artists"
+        },
+        "library-songs": {
+            "data": [
+                {
+                    "attributes": {
+                        "albumName": "War",
+                        "artistName": "U2",
+                        "artwork": {
+                            "height": 1200,
+                            "url": "https://example.mzstatic.com/image/thumb/Music/4b/ca/43/mzi.bxlrvukd.jpg/{w}x{h}bb.jpg",
+                            "width": 1200
+                        },
+                        "name": "\"40\"",
+                        "playParams": {
+                            "id": "i.dlvVYxxTPLaemG",
+                            "isLibrary": true,
+                            "kind": "song"
+                        },
+                        "trackNumber": 10
+                    },
+                    "href": "/v1/me/library/songs/i.dlvVYxxTPLaemG",
+                    "id": "i.dlvVYxxTPLaemG",
+                    "type": "library-songs"
+                }
+            ],
+            "href": "/v1/me/library/search?limit=1&term=u2&types=library-songs",
+            "next": "/v1/me/library/search?offset=1&term=u2&types=library-songs"
+        }
+    }
+}`)
+
+var librarySearch = &LibrarySearch{
+	Results: LibrarySearchResults{
+		LibraryArtists: &LibraryArtists{
+			Data: []LibraryArtist{
+				{
+					Attributes: LibraryArtistAttributes{
+						Name: "U2",
+					},
+					Href: "/v1/me/library/artists/r.2N8Fv0k",
+					Id:   "r.2N8Fv0k",
+					Type: "library-artists",
+				},
+			},
+			Href: "/v1/me/library/search?limit=1&term=u2&types=library-artists",
+			Next: "/v1/me/library/search?offset=1&term=u2&types=library-artists",
+		},
+		LibrarySongs: &LibrarySongs{
+			Data: []LibrarySong{
+				{
+					Attributes: LibrarySongAttributes{
+						AlbumName:  "War",
+						ArtistName: "U2",
+						Artwork: Artwork{
+							Height: 1200,
+							URL:    "https://example.mzstatic.com/image/thumb/Music/4b/ca/43/mzi.bxlrvukd.jpg/{w}x{h}bb.jpg",
+							Width:  1200,
+						},
+						Name: "\"40\"",
+						PlayParams: PlayParameters{
+							Id:        "i.dlvVYxxTPLaemG",
+							IsLibrary: true,
+							Kind:      "song",
+						},
+						TrackNumber: 10,
+					},
+					Href: "/v1/me/library/songs/i.dlvVYxxTPLaemG",
+					Id:   "i.dlvVYxxTPLaemG",
+					Type: "library-songs",
+				},
+			},
+			Href: "/v1/me/library/search?limit=1&term=u2&types=library-songs",
+			Next: "/v1/me/library/search?offset=1&term=u2&types=library-songs",
+		},
+	},
+}
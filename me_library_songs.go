@@ -1,6 +1,9 @@
 package applemusic
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // LibrarySongAttributes represents the attributes of library song object.
 type LibrarySongAttributes struct {
@@ -9,18 +12,26 @@ type LibrarySongAttributes struct {
 	Artwork          Artwork        `json:"artwork"`
 	ContentRating    string         `json:"contentRating,omitempty"`
 	DiscNumber       int            `json:"discNumber"`
-	DurationInMillis int64          `json:"durationInMillis,omitempty"`
+	DurationInMillis Milliseconds   `json:"durationInMillis,omitempty"`
 	Name             string         `json:"name"`
 	PlayParams       PlayParameters `json:"playParams,omitempty"`
 	TrackNumber      int            `json:"trackNumber"`
 }
 
+// LibrarySongRelationships represents a to-one or to-many relationship from one resource object to others.
+type LibrarySongRelationships struct {
+	Albums  *LibraryAlbums  `json:"albums,omitempty"`  // The library albums of the song. Default inclusion: None
+	Artists *LibraryArtists `json:"artists,omitempty"` // The library artists of the song. Default inclusion: None
+	Catalog *Songs          `json:"catalog,omitempty"` // The catalog song of the library song. Default inclusion: None
+}
+
 // LibrarySong represents a Resource object that represents a library song.
 type LibrarySong struct {
-	Id         string                `json:"id"`
-	Type       string                `json:"type"`
-	Href       string                `json:"href,omitempty"`
-	Attributes LibrarySongAttributes `json:"attributes,omitempty"`
+	Id            string                   `json:"id"`
+	Type          string                   `json:"type"`
+	Href          string                   `json:"href,omitempty"`
+	Attributes    LibrarySongAttributes    `json:"attributes,omitempty"`
+	Relationships LibrarySongRelationships `json:"relationships,omitempty"`
 }
 
 // LibrarySongs represents a list of library songs.
@@ -56,3 +67,51 @@ func (s *MeService) GetAllLibrarySongs(ctx context.Context, opt *PageOptions) (*
 
 	return s.getLibrarySongs(ctx, u, opt)
 }
+
+// GetLibrarySong fetches a library song using its identifier.
+func (s *MeService) GetLibrarySong(ctx context.Context, id string, opt *Options) (*LibrarySongs, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/songs/%s", id)
+
+	return s.getLibrarySongs(ctx, u, opt)
+}
+
+// GetLibrarySongsByIds fetches one or more library songs using their identifiers.
+func (s *MeService) GetLibrarySongsByIds(ctx context.Context, ids []string, opt *Options) (*LibrarySongs, *Response, error) {
+	u := "v1/me/library/songs"
+	librarySongs := &LibrarySongs{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, librarySongs, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return librarySongs, resp, nil
+}
+
+// GetLibrarySongAlbums fetches the library albums of a library song using its identifier.
+func (s *MeService) GetLibrarySongAlbums(ctx context.Context, id string, opt *PageOptions) (*LibraryAlbums, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/songs/%s/albums", id)
+
+	return s.getLibraryAlbums(ctx, u, opt)
+}
+
+// GetLibrarySongArtists fetches the library artists of a library song using its identifier.
+func (s *MeService) GetLibrarySongArtists(ctx context.Context, id string, opt *PageOptions) (*LibraryArtists, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/songs/%s/artists", id)
+
+	return s.getLibraryArtists(ctx, u, opt)
+}
+
+// GetLibrarySongCatalog fetches the catalog song of a library song using its identifier.
+func (s *MeService) GetLibrarySongCatalog(ctx context.Context, id string, opt *Options) (*Songs, *Response, error) {
+	u := fmt.Sprintf("v1/me/library/songs/%s/catalog", id)
+
+	songs := &Songs{}
+	resp, err := s.getLibraryRelationship(ctx, u, opt, songs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return songs, resp, nil
+}
@@ -129,3 +129,164 @@ var librarySongs = &LibrarySongs{
 	Href: "/v1/me/library/songs",
 	Next: "/v1/me/library/songs?offset=100",
 }
+
+func TestMeService_GetLibrarySong(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs/i.vMXdDeVhKQWRAd", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"include": "catalog",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(librarySongJSON)
+	})
+
+	got, _, err := client.Me.GetLibrarySong(context.Background(), "i.vMXdDeVhKQWRAd", &Options{Include: []Relationship{RelationshipCatalog}})
+	if err != nil {
+		t.Errorf("Me.GetLibrarySong returned error: %v", err)
+	}
+	if want := librarySong; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibrarySong = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibrarySongsByIds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids":     "i.vMXdDeVhKQWRAd",
+			"include": "catalog",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(librarySongJSON)
+	})
+
+	got, _, err := client.Me.GetLibrarySongsByIds(context.Background(), []string{"i.vMXdDeVhKQWRAd"}, &Options{Include: []Relationship{RelationshipCatalog}})
+	if err != nil {
+		t.Errorf("Me.GetLibrarySongsByIds returned error: %v", err)
+	}
+	if want := librarySong; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibrarySongsByIds = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibrarySongArtists(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs/i.vMXdDeVhKQWRAd/artists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"r.2N8Fv0k","type":"library-artists","attributes":{"name":"U2"}}]}`))
+	})
+
+	got, _, err := client.Me.GetLibrarySongArtists(context.Background(), "i.vMXdDeVhKQWRAd", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibrarySongArtists returned error: %v", err)
+	}
+	want := &LibraryArtists{
+		Data: []LibraryArtist{
+			{Id: "r.2N8Fv0k", Type: "library-artists", Attributes: LibraryArtistAttributes{Name: "U2"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibrarySongArtists = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibrarySongCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs/i.vMXdDeVhKQWRAd/catalog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"l": "en-gb",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"1440881325","type":"songs","href":"/v1/catalog/us/songs/1440881325","attributes":{"name":"40"}}]}`))
+	})
+
+	got, _, err := client.Me.GetLibrarySongCatalog(context.Background(), "i.vMXdDeVhKQWRAd", &Options{Language: "en-gb"})
+	if err != nil {
+		t.Errorf("Me.GetLibrarySongCatalog returned error: %v", err)
+	}
+	want := &Songs{
+		Data: []Song{
+			{
+				Id:         "1440881325",
+				Type:       "songs",
+				Href:       "/v1/catalog/us/songs/1440881325",
+				Attributes: SongAttributes{Name: "40"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibrarySongCatalog = %+v, want %+v", got, want)
+	}
+}
+
+var librarySongJSON = []byte(`{
+    "data": [
+        {
+            "attributes": {
+                "albumName": "War",
+                "artistName": "U2",
+                "name": "\"40\"",
+                "trackNumber": 10
+            },
+            "href": "/v1/me/library/songs/i.vMXdDeVhKQWRAd",
+            "id": "i.vMXdDeVhKQWRAd",
+            "relationships": {
+                "catalog": {
+                    "data": [
+                        {
+                            "href": "/v1/catalog/us/songs/1440881325",
+                            "id": "1440881325",
+                            "type": "songs"
+                        }
+                    ],
+                    "href": "/v1/me/library/songs/i.vMXdDeVhKQWRAd/catalog"
+                }
+            },
+            "type": "library-songs"
+        }
+    ]
+}`)
+
+var librarySong = &LibrarySongs{
+	Data: []LibrarySong{
+		{
+			Id:   "i.vMXdDeVhKQWRAd",
+			Type: "library-songs",
+			Href: "/v1/me/library/songs/i.vMXdDeVhKQWRAd",
+			Attributes: LibrarySongAttributes{
+				AlbumName:   "War",
+				ArtistName:  "U2",
+				Name:        "\"40\"",
+				TrackNumber: 10,
+			},
+			Relationships: LibrarySongRelationships{
+				Catalog: &Songs{
+					Data: []Song{
+						{
+							Id:   "1440881325",
+							Type: "songs",
+							Href: "/v1/catalog/us/songs/1440881325",
+						},
+					},
+					Href: "/v1/me/library/songs/i.vMXdDeVhKQWRAd/catalog",
+				},
+			},
+		},
+	},
+}
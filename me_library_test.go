@@ -0,0 +1,35 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestMeService_AddToLibrary(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"ids[albums]": "1106659171",
+			"ids[songs]":  "1107054256,1107054257",
+		})
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	opt := &AddToLibraryOptions{
+		Albums: []string{"1106659171"},
+		Songs:  []string{"1107054256", "1107054257"},
+	}
+
+	resp, err := client.Me.AddToLibrary(context.Background(), opt)
+	if err != nil {
+		t.Errorf("Me.AddToLibrary returned error: %v", err)
+	}
+	if got, want := resp.StatusCode, http.StatusAccepted; got != want {
+		t.Errorf("Me.AddToLibrary status code = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+)
+
+// RatingType represents the type of resource a rating applies to.
+type RatingType string
+
+const (
+	// RatingTypeAlbums is the rating of a catalog album.
+	RatingTypeAlbums = RatingType("albums")
+
+	// RatingTypeMusicVideos is the rating of a catalog music video.
+	RatingTypeMusicVideos = RatingType("music-videos")
+
+	// RatingTypePlaylists is the rating of a catalog playlist.
+	RatingTypePlaylists = RatingType("playlists")
+
+	// RatingTypeSongs is the rating of a catalog song.
+	RatingTypeSongs = RatingType("songs")
+
+	// RatingTypeStations is the rating of a catalog station.
+	RatingTypeStations = RatingType("stations")
+
+	// RatingTypeLibraryAlbums is the rating of a library album.
+	RatingTypeLibraryAlbums = RatingType("library-albums")
+
+	// RatingTypeLibraryMusicVideos is the rating of a library music video.
+	RatingTypeLibraryMusicVideos = RatingType("library-music-videos")
+
+	// RatingTypeLibraryPlaylists is the rating of a library playlist.
+	RatingTypeLibraryPlaylists = RatingType("library-playlists")
+
+	// RatingTypeLibrarySongs is the rating of a library song.
+	RatingTypeLibrarySongs = RatingType("library-songs")
+)
+
+// RatingValue represents the value of a rating.
+type RatingValue int
+
+const (
+	// RatingLike is the value of a like (loved) rating.
+	RatingLike = RatingValue(1)
+
+	// RatingDislike is the value of a dislike rating.
+	RatingDislike = RatingValue(-1)
+)
+
+// RatingAttributes represents the attributes of the resource.
+type RatingAttributes struct {
+	Value RatingValue `json:"value"`
+}
+
+// Rating represents a rating for a resource.
+type Rating struct {
+	Id         string           `json:"id"`
+	Type       string           `json:"type"`
+	Href       string           `json:"href"`
+	Attributes RatingAttributes `json:"attributes"`
+}
+
+// Ratings represents a list of ratings.
+type Ratings struct {
+	Data []Rating `json:"data"`
+	Href string   `json:"href,omitempty"`
+	Next string   `json:"next,omitempty"`
+}
+
+type addRating struct {
+	Type       string           `json:"type"`
+	Attributes RatingAttributes `json:"attributes"`
+}
+
+func (s *MeService) doRatings(ctx context.Context, method, u string, body interface{}) (*Ratings, *Response, error) {
+	req, err := s.client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ratings := &Ratings{}
+	resp, err := s.client.Do(ctx, req, ratings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ratings, resp, nil
+}
+
+// GetRating fetches the user’s personal rating for a resource using its identifier.
+func (s *MeService) GetRating(ctx context.Context, typ RatingType, id string, opt *Options) (*Ratings, *Response, error) {
+	u := fmt.Sprintf("v1/me/ratings/%s/%s", typ, id)
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.doRatings(ctx, "GET", u, nil)
+}
+
+// GetRatingsByIds fetches the user’s personal ratings for one or more resources using their identifiers.
+func (s *MeService) GetRatingsByIds(ctx context.Context, typ RatingType, ids []string, opt *Options) (*Ratings, *Response, error) {
+	u := fmt.Sprintf("v1/me/ratings/%s", typ)
+	ratings := &Ratings{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, ratings, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ratings, resp, nil
+}
+
+// AddRating adds or replaces the user’s personal rating for a resource using its identifier.
+func (s *MeService) AddRating(ctx context.Context, typ RatingType, id string, value RatingValue) (*Ratings, *Response, error) {
+	u := fmt.Sprintf("v1/me/ratings/%s/%s", typ, id)
+	body := addRating{
+		Type:       "rating",
+		Attributes: RatingAttributes{Value: value},
+	}
+
+	return s.doRatings(ctx, "PUT", u, body)
+}
+
+// DeleteRating removes the user’s personal rating for a resource using its identifier.
+func (s *MeService) DeleteRating(ctx context.Context, typ RatingType, id string) (*Response, error) {
+	u := fmt.Sprintf("v1/me/ratings/%s/%s", typ, id)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,117 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var songRatingJSON = []byte(`{
+    "data": [
+        {
+            "attributes": {
+                "value": 1
+            },
+            "href": "/v1/me/ratings/songs/1297791838",
+            "id": "1297791838",
+            "type": "ratings"
+        }
+    ]
+}`)
+
+var songRating = &Ratings{
+	Data: []Rating{
+		{
+			Attributes: RatingAttributes{
+				Value: RatingLike,
+			},
+			Href: "/v1/me/ratings/songs/1297791838",
+			Id:   "1297791838",
+			Type: "ratings",
+		},
+	},
+}
+
+func TestMeService_GetRating(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/ratings/songs/1297791838", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(songRatingJSON)
+	})
+
+	got, _, err := client.Me.GetRating(context.Background(), RatingTypeSongs, "1297791838", nil)
+	if err != nil {
+		t.Errorf("Me.GetRating returned error: %v", err)
+	}
+	if want := songRating; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetRating = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetRatingsByIds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/ratings/songs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids": "1297791838,1297791839",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(songRatingJSON)
+	})
+
+	got, _, err := client.Me.GetRatingsByIds(context.Background(), RatingTypeSongs, []string{"1297791838", "1297791839"}, nil)
+	if err != nil {
+		t.Errorf("Me.GetRatingsByIds returned error: %v", err)
+	}
+	if want := songRating; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetRatingsByIds = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_AddRating(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/ratings/songs/1297791838", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testJsonBodyValues(t, r, []byte(`{"type":"rating","attributes":{"value":1}}`))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(songRatingJSON)
+	})
+
+	got, _, err := client.Me.AddRating(context.Background(), RatingTypeSongs, "1297791838", RatingLike)
+	if err != nil {
+		t.Errorf("Me.AddRating returned error: %v", err)
+	}
+	if want := songRating; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.AddRating = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_DeleteRating(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/ratings/library-playlists/p.MoGJYM3CYXW09B", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.Me.DeleteRating(context.Background(), RatingTypeLibraryPlaylists, "p.MoGJYM3CYXW09B")
+	if err != nil {
+		t.Errorf("Me.DeleteRating returned error: %v", err)
+	}
+	if got, want := resp.StatusCode, http.StatusNoContent; got != want {
+		t.Errorf("Me.DeleteRating status code = %v, want %v", got, want)
+	}
+}
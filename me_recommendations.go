@@ -0,0 +1,118 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+)
+
+// RecommendationText represents a localized text of a recommendation.
+type RecommendationText struct {
+	StringForDisplay string `json:"stringForDisplay"`
+}
+
+// RecommendationAttributes represents the attributes of the resource.
+type RecommendationAttributes struct {
+	IsGroupRecommendation bool                `json:"isGroupRecommendation"`
+	NextUpdateDate        Date                `json:"nextUpdateDate"`
+	Reason                *RecommendationText `json:"reason,omitempty"`
+	ResourceTypes         []string            `json:"resourceTypes"`
+	Title                 *RecommendationText `json:"title,omitempty"`
+}
+
+// RecommendationContents represents a list of the albums, playlists and stations of a recommendation.
+type RecommendationContents struct {
+	Data []Resource `json:"data"`
+	Href string     `json:"href,omitempty"`
+	Next string     `json:"next,omitempty"`
+}
+
+// Albums returns the albums in the contents.
+func (c RecommendationContents) Albums() ([]Album, error) {
+	var albums []Album
+	err := parseResources(c.Data, "albums", &albums)
+	return albums, err
+}
+
+// Playlists returns the playlists in the contents.
+func (c RecommendationContents) Playlists() ([]Playlist, error) {
+	var playlists []Playlist
+	err := parseResources(c.Data, "playlists", &playlists)
+	return playlists, err
+}
+
+// Stations returns the stations in the contents.
+func (c RecommendationContents) Stations() ([]Station, error) {
+	var stations []Station
+	err := parseResources(c.Data, "stations", &stations)
+	return stations, err
+}
+
+// RecommendationRelationships represents a to-one or to-many relationship from one resource object to others.
+type RecommendationRelationships struct {
+	Contents        *RecommendationContents `json:"contents,omitempty"`        // Default inclusion: Objects
+	Recommendations *Recommendations        `json:"recommendations,omitempty"` // The recommendations of a group recommendation. Default inclusion: Objects
+}
+
+// Recommendation represents a personal recommendation.
+type Recommendation struct {
+	Id            string                      `json:"id"`
+	Type          string                      `json:"type"`
+	Href          string                      `json:"href"`
+	Attributes    RecommendationAttributes    `json:"attributes"`
+	Relationships RecommendationRelationships `json:"relationships"`
+}
+
+// Recommendations represents a list of recommendations.
+type Recommendations struct {
+	Data []Recommendation `json:"data"`
+	Href string           `json:"href,omitempty"`
+	Next string           `json:"next,omitempty"`
+}
+
+func (s *MeService) getRecommendations(ctx context.Context, u string, opt interface{}) (*Recommendations, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recommendations := &Recommendations{}
+	resp, err := s.client.Do(ctx, req, recommendations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return recommendations, resp, nil
+}
+
+// GetRecommendations fetches the default recommendations.
+func (s *MeService) GetRecommendations(ctx context.Context, opt *PageOptions) (*Recommendations, *Response, error) {
+	u := "v1/me/recommendations"
+
+	return s.getRecommendations(ctx, u, opt)
+}
+
+// GetRecommendation fetches a recommendation using its identifier.
+func (s *MeService) GetRecommendation(ctx context.Context, id string, opt *Options) (*Recommendations, *Response, error) {
+	u := fmt.Sprintf("v1/me/recommendations/%s", id)
+
+	return s.getRecommendations(ctx, u, opt)
+}
+
+// GetRecommendationsByIds fetches one or more recommendations using their identifiers.
+func (s *MeService) GetRecommendationsByIds(ctx context.Context, ids []string, opt *Options) (*Recommendations, *Response, error) {
+	u := "v1/me/recommendations"
+	recommendations := &Recommendations{}
+	resp, err := s.client.getInBatches(ctx, ids, maxLibraryIdsPerRequest, recommendations, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return recommendations, resp, nil
+}
@@ -0,0 +1,180 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMeService_GetRecommendations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/recommendations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"limit": "1",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(recommendationsJSON)
+	})
+
+	got, _, err := client.Me.GetRecommendations(context.Background(), &PageOptions{Limit: 1})
+	if err != nil {
+		t.Errorf("Me.GetRecommendations returned error: %v", err)
+	}
+	if want := recommendations; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetRecommendations = %+v, want %+v", got, want)
+	}
+
+	albums, err := got.Data[0].Relationships.Contents.Albums()
+	if err != nil {
+		t.Errorf("RecommendationContents.Albums returned error: %v", err)
+	}
+	if len(albums) != 1 || albums[0].Attributes.Name != "Lemonade" {
+		t.Errorf("RecommendationContents.Albums = %+v, want the Lemonade album", albums)
+	}
+	playlists, err := got.Data[0].Relationships.Contents.Playlists()
+	if err != nil {
+		t.Errorf("RecommendationContents.Playlists returned error: %v", err)
+	}
+	if len(playlists) != 1 || playlists[0].Id != "pl.2ff0e502db0c44a598a7cb2261a5e6b2" {
+		t.Errorf("RecommendationContents.Playlists = %+v, want one playlist", playlists)
+	}
+}
+
+func TestMeService_GetRecommendation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/recommendations/6-27s5hU6azhJY", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(recommendationsJSON)
+	})
+
+	got, _, err := client.Me.GetRecommendation(context.Background(), "6-27s5hU6azhJY", nil)
+	if err != nil {
+		t.Errorf("Me.GetRecommendation returned error: %v", err)
+	}
+	if want := recommendations; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetRecommendation = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetRecommendationsByIds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/recommendations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids": "6-27s5hU6azhJY,6-27s5hU6azhJZ",
+		})
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(recommendationsJSON)
+	})
+
+	got, _, err := client.Me.GetRecommendationsByIds(context.Background(), []string{"6-27s5hU6azhJY", "6-27s5hU6azhJZ"}, nil)
+	if err != nil {
+		t.Errorf("Me.GetRecommendationsByIds returned error: %v", err)
+	}
+	if want := recommendations; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetRecommendationsByIds = %+v, want %+v", got, want)
+	}
+}
+
+var recommendationsJSON = []byte(`{
+    "data": [
+        {
+            "attributes": {
+                "isGroupRecommendation": false,
+                "nextUpdateDate": "2018-01-01T00:00:00Z",
+                "reason": {
+                    "stringForDisplay": "Because you listened to Beyoncé"
+                },
+                "resourceTypes": [
+                    "albums",
+                    "playlists"
+                ],
+                "title": {
+                    "stringForDisplay": "Recommended Albums"
+                }
+            },
+            "href": "/v1/me/recommendations/6-27s5hU6azhJY",
+            "id": "6-27s5hU6azhJY",
+            "relationships": {
+                "contents": {
+                    "data": [
+                        {
+                            "attributes": {
+                                "name": "Lemonade"
+                            },
+                            "href": "/v1/catalog/us/albums/1107054256",
+                            "id": "1107054256",
+                            "type": "albums"
+                        },
+                        {
+                            "href": "/v1/catalog/us/playlists/pl.2ff0e502db0c44a598a7cb2261a5e6b2",
+                            "id": "pl.2ff0e502db0c44a598a7cb2261a5e6b2",
+                            "type": "playlists"
+                        }
+                    ]
+                }
+            },
+            "type": "personal-recommendation"
+        }
+    ],
+    "href": "/v1/me/recommendations?limit=1",
+    "next": "/v1/me/recommendations?offset=1"
+}`)
+
+var recommendations = &Recommendations{
+	Data: []Recommendation{
+		{
+			Attributes: RecommendationAttributes{
+				IsGroupRecommendation: false,
+				NextUpdateDate:        "2018-01-01T00:00:00Z",
+				Reason: &RecommendationText{
+					StringForDisplay: "Because you listened to Beyoncé",
+				},
+				ResourceTypes: []string{"albums", "playlists"},
+				Title: &RecommendationText{
+					StringForDisplay: "Recommended Albums",
+				},
+			},
+			Href: "/v1/me/recommendations/6-27s5hU6azhJY",
+			Id:   "6-27s5hU6azhJY",
+			Relationships: RecommendationRelationships{
+				Contents: &RecommendationContents{
+					Data: []Resource{
+						{
+							[]byte(`{
+                            "attributes": {
+                                "name": "Lemonade"
+                            },
+                            "href": "/v1/catalog/us/albums/1107054256",
+                            "id": "1107054256",
+                            "type": "albums"
+                        }`),
+						},
+						{
+							[]byte(`{
+                            "href": "/v1/catalog/us/playlists/pl.2ff0e502db0c44a598a7cb2261a5e6b2",
+                            "id": "pl.2ff0e502db0c44a598a7cb2261a5e6b2",
+                            "type": "playlists"
+                        }`),
+						},
+					},
+				},
+			},
+			Type: "personal-recommendation",
+		},
+	},
+	Href: "/v1/me/recommendations?limit=1",
+	Next: "/v1/me/recommendations?offset=1",
+}
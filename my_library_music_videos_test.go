@@ -72,3 +72,57 @@ var libraryMusicVideos = &LibraryMusicVideos{
 		},
 	},
 }
+
+func TestMeService_GetLibraryMusicVideo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/music-videos/i.PmDkr2ptb9VQ", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"i.PmDkr2ptb9VQ","type":"library-music-videos","attributes":{"artistName":"U2","name":"Magnificent"}}]}`))
+	})
+
+	got, _, err := client.Me.GetLibraryMusicVideo(context.Background(), "i.PmDkr2ptb9VQ", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryMusicVideo returned error: %v", err)
+	}
+	want := &LibraryMusicVideos{
+		Data: []LibraryMusicVideo{
+			{
+				Id:         "i.PmDkr2ptb9VQ",
+				Type:       "library-music-videos",
+				Attributes: LibraryMusicVideoAttributes{ArtistName: "U2", Name: "Magnificent"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryMusicVideo = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeService_GetLibraryMusicVideoCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/music-videos/i.PmDkr2ptb9VQ/catalog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"401135199","type":"music-videos","href":"/v1/catalog/us/music-videos/401135199"}]}`))
+	})
+
+	got, _, err := client.Me.GetLibraryMusicVideoCatalog(context.Background(), "i.PmDkr2ptb9VQ", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryMusicVideoCatalog returned error: %v", err)
+	}
+	want := &MusicVideos{
+		Data: []MusicVideo{
+			{Id: "401135199", Type: "music-videos", Href: "/v1/catalog/us/music-videos/401135199"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryMusicVideoCatalog = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/google/go-querystring/query"
+)
+
+// PaginateOptions specifies the optional parameters to the Client.Paginate and Client.CollectAll methods.
+type PaginateOptions struct {
+	// The maximum number of pages to walk, including the page the pagination starts from.
+	// Zero means no limit.
+	MaxPages int
+
+	// The maximum number of objects to walk, including the objects of the page the pagination starts from.
+	// Zero means no limit.
+	MaxItems int
+
+	// The options of the first request, such as its PageOptions, added to the Next links that lack them,
+	// since the API does not carry the limit and the included relationships over to the Next links.
+	Options interface{}
+}
+
+// PageFunc is the type of the function called by Client.Paginate for each fetched page.
+// The page is a pointer of the same type as the page the pagination started from.
+// If the function returns an error, the pagination stops and Paginate returns that error.
+type PageFunc func(page interface{}, resp *Response) error
+
+// collection wraps a pointer to a collection type, a struct with a Data slice and a Next link.
+type collection struct {
+	v reflect.Value
+}
+
+func newCollection(page interface{}) (collection, error) {
+	v := reflect.ValueOf(page)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return collection{}, fmt.Errorf("applemusic: page must be a non-nil pointer to a collection struct, got %T", page)
+	}
+	s := v.Elem()
+	if f := s.FieldByName("Data"); !f.IsValid() || f.Kind() != reflect.Slice {
+		return collection{}, fmt.Errorf("applemusic: %T has no Data slice", page)
+	}
+	if f := s.FieldByName("Next"); !f.IsValid() || f.Kind() != reflect.String {
+		return collection{}, fmt.Errorf("applemusic: %T has no Next link", page)
+	}
+	return collection{v: v}, nil
+}
+
+func (c collection) data() reflect.Value {
+	return c.v.Elem().FieldByName("Data")
+}
+
+func (c collection) next() string {
+	return c.v.Elem().FieldByName("Next").String()
+}
+
+// Paginate follows the Next links starting from page and calls fn with each subsequent page and its response.
+// The page must be a pointer to a collection type, such as *Songs or *LibraryPlaylists,
+// usually the one returned by the first call to a service method.
+//
+// Pagination stops when there is no Next link, when a limit of opt is reached or when fn returns an error.
+// The ctx is checked between pages, if it is canceled or time out, ctx.Err() will be returned.
+func (c *Client) Paginate(ctx context.Context, page interface{}, opt *PaginateOptions, fn PageFunc) error {
+	cur, err := newCollection(page)
+	if err != nil {
+		return err
+	}
+	if opt == nil {
+		opt = &PaginateOptions{}
+	}
+
+	pages, items := 1, cur.data().Len()
+	for {
+		next := cur.next()
+		if next == "" ||
+			(opt.MaxPages > 0 && pages >= opt.MaxPages) ||
+			(opt.MaxItems > 0 && items >= opt.MaxItems) {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next, err := addPageOptions(next, opt.Options)
+		if err != nil {
+			return err
+		}
+
+		req, err := c.NewRequest("GET", next, nil)
+		if err != nil {
+			return err
+		}
+
+		v := reflect.New(cur.v.Elem().Type())
+		resp, err := c.Do(ctx, req, v.Interface())
+		if err != nil {
+			return err
+		}
+
+		cur = collection{v: v}
+		pages++
+		items += cur.data().Len()
+
+		if err := fn(v.Interface(), resp); err != nil {
+			return err
+		}
+	}
+}
+
+// addPageOptions adds the parameters of opt to the Next link u, except the ones the link already has,
+// such as its offset.
+func addPageOptions(u string, opt interface{}) (string, error) {
+	v := reflect.ValueOf(opt)
+	if !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return u, nil
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return u, err
+	}
+	qs, err := query.Values(opt)
+	if err != nil {
+		return u, err
+	}
+
+	values := parsed.Query()
+	for k, v := range qs {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+	parsed.RawQuery = values.Encode()
+	return parsed.String(), nil
+}
+
+// CollectAll follows the Next links starting from page and appends the objects of every subsequent page to the
+// Data of page. When opt.MaxItems is set, the Data is truncated to that many objects.
+// The Next link of page is set to the Next link of the last fetched page, so it is empty once the
+// collection is exhausted.
+//
+// CollectAll returns the responses of the fetched pages. On error, page holds the objects collected so far.
+func (c *Client) CollectAll(ctx context.Context, page interface{}, opt *PaginateOptions) ([]*Response, error) {
+	dst, err := newCollection(page)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*Response
+	err = c.Paginate(ctx, page, opt, func(p interface{}, resp *Response) error {
+		src := collection{v: reflect.ValueOf(p)}
+		data := dst.data()
+		data.Set(reflect.AppendSlice(data, src.data()))
+		dst.v.Elem().FieldByName("Next").SetString(src.next())
+		responses = append(responses, resp)
+		return nil
+	})
+
+	if opt != nil && opt.MaxItems > 0 {
+		if data := dst.data(); data.Len() > opt.MaxItems {
+			data.Set(data.Slice(0, opt.MaxItems))
+		}
+	}
+
+	return responses, err
+}
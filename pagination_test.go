@@ -0,0 +1,249 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func setupPages() {
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"i.1","type":"library-songs"}],"next":"/v1/me/library/songs?offset=1"}`)
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":"i.2","type":"library-songs"}],"next":"/v1/me/library/songs?offset=2"}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":"i.3","type":"library-songs"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestClient_Paginate(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPages()
+
+	page, _, err := client.Me.GetAllLibrarySongs(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Me.GetAllLibrarySongs returned error: %v", err)
+	}
+
+	var got []string
+	err = client.Paginate(context.Background(), page, nil, func(p interface{}, resp *Response) error {
+		if resp == nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("Paginate response = %+v, want 200 OK", resp)
+		}
+		for _, song := range p.(*LibrarySongs).Data {
+			got = append(got, song.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Paginate returned error: %v", err)
+	}
+	if want := []string{"i.2", "i.3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Paginate pages = %v, want %v", got, want)
+	}
+}
+
+func TestClient_Paginate_fnError(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPages()
+
+	page, _, _ := client.Me.GetAllLibrarySongs(context.Background(), nil)
+
+	calls := 0
+	stop := fmt.Errorf("stop")
+	err := client.Paginate(context.Background(), page, nil, func(interface{}, *Response) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Paginate returned error %v, want %v", err, stop)
+	}
+	if calls != 1 {
+		t.Errorf("Paginate called fn %d times, want 1", calls)
+	}
+}
+
+func TestClient_Paginate_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPages()
+
+	page, _, _ := client.Me.GetAllLibrarySongs(context.Background(), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.Paginate(ctx, page, nil, func(interface{}, *Response) error {
+		t.Error("Paginate called fn with a canceled context")
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Paginate returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_Paginate_invalidPage(t *testing.T) {
+	c := NewClient(nil)
+	for _, page := range []interface{}{nil, LibrarySongs{}, (*LibrarySongs)(nil), &Charts{}} {
+		if err := c.Paginate(context.Background(), page, nil, nil); err == nil {
+			t.Errorf("Paginate(%T) expected error to be returned", page)
+		}
+	}
+}
+
+func TestClient_CollectAll(t *testing.T) {
+	setup()
+	defer teardown()
+	setupPages()
+
+	page, _, _ := client.Me.GetAllLibrarySongs(context.Background(), nil)
+
+	responses, err := client.CollectAll(context.Background(), page, nil)
+	if err != nil {
+		t.Errorf("CollectAll returned error: %v", err)
+	}
+	if got, want := len(responses), 2; got != want {
+		t.Errorf("CollectAll returned %d responses, want %d", got, want)
+	}
+
+	want := &LibrarySongs{
+		Data: []LibrarySong{
+			{Id: "i.1", Type: "library-songs"},
+			{Id: "i.2", Type: "library-songs"},
+			{Id: "i.3", Type: "library-songs"},
+		},
+	}
+	if !reflect.DeepEqual(page, want) {
+		t.Errorf("CollectAll = %+v, want %+v", page, want)
+	}
+}
+
+func TestClient_CollectAll_limits(t *testing.T) {
+	testCases := []struct {
+		opt  *PaginateOptions
+		ids  []string
+		next string
+	}{
+		{
+			opt:  &PaginateOptions{MaxPages: 1},
+			ids:  []string{"i.1"},
+			next: "/v1/me/library/songs?offset=1",
+		},
+		{
+			opt:  &PaginateOptions{MaxPages: 2},
+			ids:  []string{"i.1", "i.2"},
+			next: "/v1/me/library/songs?offset=2",
+		},
+		{
+			opt:  &PaginateOptions{MaxItems: 2},
+			ids:  []string{"i.1", "i.2"},
+			next: "/v1/me/library/songs?offset=2",
+		},
+	}
+	for k, tc := range testCases {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			setup()
+			defer teardown()
+			setupPages()
+
+			page, _, _ := client.Me.GetAllLibrarySongs(context.Background(), nil)
+			if _, err := client.CollectAll(context.Background(), page, tc.opt); err != nil {
+				t.Errorf("CollectAll returned error: %v", err)
+			}
+
+			var ids []string
+			for _, song := range page.Data {
+				ids = append(ids, song.Id)
+			}
+			if !reflect.DeepEqual(ids, tc.ids) {
+				t.Errorf("CollectAll ids = %v, want %v", ids, tc.ids)
+			}
+			if page.Next != tc.next {
+				t.Errorf("CollectAll next = %v, want %v", page.Next, tc.next)
+			}
+		})
+	}
+}
+
+func TestMeService_GetLibraryPlaylistTracks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/playlists/p.1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("offset") == "" {
+			fmt.Fprint(w, `{"data":[{"id":"i.1"}],"next":"/v1/me/library/playlists/p.1/tracks?offset=1","meta":{"total":2}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"i.2"}],"meta":{"total":2}}`)
+	})
+
+	got, total, err := client.Me.GetLibraryPlaylistTracks(context.Background(), "p.1", nil)
+	if err != nil {
+		t.Errorf("Me.GetLibraryPlaylistTracks returned error: %v", err)
+	}
+	if want := []Song{{Id: "i.1"}, {Id: "i.2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryPlaylistTracks = %+v, want %+v", got, want)
+	}
+	if total != 2 {
+		t.Errorf("Me.GetLibraryPlaylistTracks total = %d, want 2", total)
+	}
+}
+
+func TestMeService_GetLibraryPlaylistTracks_pageOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/playlists/p.1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("offset") == "" {
+			testFormValues(t, r, values{"include": "catalog"})
+			fmt.Fprint(w, `{"data":[{"id":"i.1"}],"next":"/v1/me/library/playlists/p.1/tracks?offset=1","meta":{"total":2}}`)
+			return
+		}
+		testFormValues(t, r, values{"include": "catalog", "offset": "1"})
+		fmt.Fprint(w, `{"data":[{"id":"i.2"}],"meta":{"total":2}}`)
+	})
+
+	opt := &PageOptions{Options: Options{Include: []Relationship{RelationshipCatalog}}}
+	got, _, err := client.Me.GetLibraryPlaylistTracks(context.Background(), "p.1", opt)
+	if err != nil {
+		t.Errorf("Me.GetLibraryPlaylistTracks returned error: %v", err)
+	}
+	if want := []Song{{Id: "i.1"}, {Id: "i.2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Me.GetLibraryPlaylistTracks = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Paginate_options(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/me/library/songs", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"limit": "2", "offset": "2"})
+		fmt.Fprint(w, `{"data":[{"id":"i.3"}]}`)
+	})
+
+	first := &LibrarySongs{Next: "/v1/me/library/songs?offset=2"}
+	opt := &PaginateOptions{Options: &PageOptions{Limit: 2, Offset: 1}}
+	calls := 0
+	err := client.Paginate(context.Background(), first, opt, func(page interface{}, _ *Response) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Paginate returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Paginate called fn %d times, want 1", calls)
+	}
+}
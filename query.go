@@ -0,0 +1,94 @@
+package applemusic
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ResourceType represents the type of a resource, such as albums or library-songs.
+type ResourceType string
+
+// The types of the catalog and library resources.
+const (
+	ResourceTypeActivities         = ResourceType("activities")
+	ResourceTypeAlbums             = ResourceType("albums")
+	ResourceTypeAppleCurators      = ResourceType("apple-curators")
+	ResourceTypeArtists            = ResourceType("artists")
+	ResourceTypeCurators           = ResourceType("curators")
+	ResourceTypeGenres             = ResourceType("genres")
+	ResourceTypeMusicVideos        = ResourceType("music-videos")
+	ResourceTypePlaylists          = ResourceType("playlists")
+	ResourceTypeSongs              = ResourceType("songs")
+	ResourceTypeStations           = ResourceType("stations")
+	ResourceTypeLibraryAlbums      = ResourceType("library-albums")
+	ResourceTypeLibraryArtists     = ResourceType("library-artists")
+	ResourceTypeLibraryMusicVideos = ResourceType("library-music-videos")
+	ResourceTypeLibraryPlaylists   = ResourceType("library-playlists")
+	ResourceTypeLibrarySongs       = ResourceType("library-songs")
+)
+
+// Relationship represents the name of a relationship of a resource, such as the tracks of an album.
+type Relationship string
+
+// The names of the relationships of the catalog and library resources.
+const (
+	RelationshipAlbums      = Relationship("albums")
+	RelationshipArtists     = Relationship("artists")
+	RelationshipCatalog     = Relationship("catalog")
+	RelationshipCurator     = Relationship("curator")
+	RelationshipGenres      = Relationship("genres")
+	RelationshipMusicVideos = Relationship("music-videos")
+	RelationshipPlaylists   = Relationship("playlists")
+	RelationshipStation     = Relationship("station")
+	RelationshipTracks      = Relationship("tracks")
+)
+
+// The documented maximum values of the limit parameters.
+const (
+	maxSearchLimit = 25
+	maxChartLimit  = 50
+)
+
+// InvalidOptionError reports an option value that the API does not accept.
+// It is returned before any request is sent, and matches ErrBadParameter with errors.Is.
+type InvalidOptionError struct {
+	Option string // The name of the option field, such as SearchOptions.Limit.
+	Reason string
+}
+
+func (e *InvalidOptionError) Error() string {
+	return fmt.Sprintf("applemusic: invalid option %s: %s", e.Option, e.Reason)
+}
+
+// Unwrap returns ErrBadParameter.
+func (e *InvalidOptionError) Unwrap() error {
+	return ErrBadParameter
+}
+
+func validateLimit(option string, limit, max int) error {
+	if limit < 0 {
+		return &InvalidOptionError{Option: option, Reason: "must not be negative"}
+	}
+	if limit > max {
+		return &InvalidOptionError{Option: option, Reason: fmt.Sprintf("must be at most %d, got %d", max, limit)}
+	}
+	return nil
+}
+
+// searchTerm returns the search term with the words separated by single spaces,
+// which are encoded as ‘+’ characters in the query string.
+// A ‘+’ character between two letters or digits, as in james+brown, separates words as well.
+func searchTerm(term string) string {
+	runes := []rune(term)
+	for i := 1; i < len(runes)-1; i++ {
+		if runes[i] == '+' && isWordRune(runes[i-1]) && isWordRune(runes[i+1]) {
+			runes[i] = ' '
+		}
+	}
+	return strings.Join(strings.Fields(string(runes)), " ")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
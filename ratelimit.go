@@ -0,0 +1,261 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// adaptiveMinRateDivisor bounds how far the adaptive rate limiting lowers a rate: down to 1/16 of the configured rate.
+const adaptiveMinRateDivisor = 16
+
+// RateLimit specifies the rate of a token bucket.
+type RateLimit struct {
+	// The number of requests per second. Zero means no limit.
+	Rate float64
+
+	// The maximum number of requests sent at once. If zero, 1 is used.
+	Burst int
+}
+
+// RateLimiter limits the rate of the requests sent by the Client with token buckets:
+// a global bucket and one bucket for each configured path prefix.
+// A request waits for the global bucket and for the bucket of the longest path prefix that matches its path.
+// The zero value has no global limit.
+// It is safe for concurrent use by multiple goroutines.
+type RateLimiter struct {
+	// Adaptive, when true, halves the rate of the buckets of a request that failed with a Too Many Requests (429) error,
+	// down to 1/16 of the configured rate, and gradually restores it with each successful response.
+	Adaptive bool
+
+	global *bucket // Nil if no global limit.
+
+	mu       sync.RWMutex
+	prefixes []string // Sorted by decreasing length.
+	buckets  map[string]*bucket
+}
+
+// NewRateLimiter returns a RateLimiter with the given global limit.
+func NewRateLimiter(global RateLimit) *RateLimiter {
+	return &RateLimiter{
+		global:  newBucket(global),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// SetPathLimit sets the limit of the requests whose path starts with prefix, such as "/v1/me/".
+func (l *RateLimiter) SetPathLimit(prefix string, limit RateLimit) {
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.buckets == nil {
+		l.buckets = map[string]*bucket{}
+	}
+	if _, ok := l.buckets[prefix]; !ok {
+		l.prefixes = append(l.prefixes, prefix)
+		sort.SliceStable(l.prefixes, func(i, j int) bool {
+			return len(l.prefixes[i]) > len(l.prefixes[j])
+		})
+	}
+	l.buckets[prefix] = newBucket(limit)
+}
+
+// Wait blocks until a request to the path is allowed.
+// If ctx is canceled, or if its deadline would be exceeded before the request is allowed, an error is returned
+// and no token is taken from any bucket.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Reserve a token of every bucket before waiting, so that a failure returns all of them.
+	now := time.Now()
+	deadline, _ := ctx.Deadline()
+	buckets := l.bucketsFor(path)
+	var delay time.Duration
+	for i, b := range buckets {
+		d, ok := b.reserve(now, deadline)
+		if !ok {
+			cancelAll(buckets[:i])
+			return context.DeadlineExceeded
+		}
+		if d > delay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		cancelAll(buckets)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe adapts the rate of the buckets of the path to the status code of a response.
+func (l *RateLimiter) observe(path string, statusCode int) {
+	if !l.Adaptive {
+		return
+	}
+	for _, b := range l.bucketsFor(path) {
+		if statusCode == http.StatusTooManyRequests {
+			b.decrease(time.Now())
+		} else if statusCode < 400 {
+			b.increase(time.Now())
+		}
+	}
+}
+
+// Rate returns the current rate of the requests to the path, in requests per second,
+// the lowest rate of the buckets of the path. Zero means no limit.
+func (l *RateLimiter) Rate(path string) float64 {
+	var rate float64
+	for _, b := range l.bucketsFor(path) {
+		b.mu.Lock()
+		r := b.rate
+		if b.limit.Rate <= 0 {
+			r = 0
+		}
+		b.mu.Unlock()
+		if r > 0 && (rate == 0 || r < rate) {
+			rate = r
+		}
+	}
+	return rate
+}
+
+func (l *RateLimiter) bucketsFor(path string) []*bucket {
+	var buckets []*bucket
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			buckets = append(buckets, l.buckets[prefix])
+			break
+		}
+	}
+	return buckets
+}
+
+// bucket is a token bucket.
+type bucket struct {
+	limit RateLimit
+
+	mu     sync.Mutex
+	rate   float64 // The current rate, lowered by the adaptive rate limiting.
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit RateLimit) *bucket {
+	if limit.Burst <= 0 {
+		limit.Burst = 1
+	}
+	return &bucket{
+		limit:  limit,
+		rate:   limit.Rate,
+		tokens: float64(limit.Burst),
+	}
+}
+
+// advance adds the tokens accumulated since the last update. The mu must be held.
+func (b *bucket) advance(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if burst := float64(b.limit.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+	b.last = now
+}
+
+// reserve takes a token and returns the delay before it is available.
+// If the deadline would be exceeded, no token is taken and false is returned.
+func (b *bucket) reserve(now time.Time, deadline time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate <= 0 {
+		return 0, true
+	}
+
+	b.advance(now)
+
+	var delay time.Duration
+	if b.tokens < 1 {
+		delay = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	if !deadline.IsZero() && now.Add(delay).After(deadline) {
+		return 0, false
+	}
+	b.tokens--
+	return delay, true
+}
+
+// cancel returns a reserved token, up to the burst.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate <= 0 {
+		return
+	}
+	b.tokens++
+	if burst := float64(b.limit.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
+
+// cancelAll returns a reserved token to each of the buckets.
+func cancelAll(buckets []*bucket) {
+	for _, b := range buckets {
+		b.cancel()
+	}
+}
+
+func (b *bucket) decrease(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate <= 0 {
+		return
+	}
+	b.advance(now)
+	b.rate /= 2
+	if floor := b.limit.Rate / adaptiveMinRateDivisor; b.rate < floor {
+		b.rate = floor
+	}
+}
+
+func (b *bucket) increase(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit.Rate <= 0 || b.rate >= b.limit.Rate {
+		return
+	}
+	b.advance(now)
+	b.rate += b.limit.Rate / adaptiveMinRateDivisor
+	if b.rate > b.limit.Rate {
+		b.rate = b.limit.Rate
+	}
+}
@@ -0,0 +1,173 @@
+package applemusic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 100, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(context.Background(), "/v1/catalog/us/songs"); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	// 2 requests of burst, then 2 requests at 100 per second.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("Wait allowed 4 requests in %v, want at least 15ms", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_deadline(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 0.1})
+
+	if err := l.Wait(context.Background(), "/"); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := l.Wait(ctx, "/"); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Wait blocked for %v, want to return immediately", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_canceled(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 0.1})
+	_ = l.Wait(context.Background(), "/")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if err := l.Wait(ctx, "/"); err != context.Canceled {
+		t.Errorf("Wait returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRateLimiter_Wait_returnsTokens(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 1, Burst: 2})
+	l.SetPathLimit("/v1/me/", RateLimit{Rate: 0.1})
+
+	if err := l.Wait(context.Background(), "/v1/me/storefront"); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, "/v1/me/storefront"); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The global token reserved by the failed request is available again.
+	if err := l.Wait(ctx, "/v1/catalog/us/songs"); err != nil {
+		t.Errorf("Wait returned error: %v", err)
+	}
+}
+
+func TestRateLimiter_zero(t *testing.T) {
+	l := &RateLimiter{}
+	if err := l.Wait(context.Background(), "/v1/me/storefront"); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if got := l.Rate("/v1/me/storefront"); got != 0 {
+		t.Errorf("Rate = %v, want 0", got)
+	}
+
+	l.SetPathLimit("/v1/me/", RateLimit{Rate: 10})
+	if got := l.Rate("/v1/me/storefront"); got != 10 {
+		t.Errorf("Rate = %v, want 10", got)
+	}
+}
+
+func Test_bucket_cancel(t *testing.T) {
+	b := newBucket(RateLimit{Rate: 1, Burst: 1})
+	if _, ok := b.reserve(time.Now(), time.Time{}); !ok {
+		t.Fatal("reserve returned false, want true")
+	}
+
+	// Tokens accumulated while waiting, the returned token is capped at the burst.
+	b.tokens = 0.5
+	b.cancel()
+	if b.tokens != 1 {
+		t.Errorf("bucket has %v tokens, want 1", b.tokens)
+	}
+}
+
+func TestRateLimiter_SetPathLimit(t *testing.T) {
+	l := NewRateLimiter(RateLimit{})
+	l.SetPathLimit("v1/me/", RateLimit{Rate: 10})
+	l.SetPathLimit("/v1/me/library/", RateLimit{Rate: 5})
+
+	testCases := []struct {
+		path string
+		want float64
+	}{
+		{"/v1/catalog/us/songs", 0},
+		{"/v1/me/storefront", 10},
+		{"/v1/me/library/songs", 5},
+	}
+	for _, tc := range testCases {
+		if got := l.Rate(tc.path); got != tc.want {
+			t.Errorf("Rate(%v) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimiter_adaptive(t *testing.T) {
+	l := NewRateLimiter(RateLimit{Rate: 16})
+	l.Adaptive = true
+
+	l.observe("/", http.StatusTooManyRequests)
+	if got, want := l.Rate("/"), 8.0; got != want {
+		t.Errorf("Rate after 429 = %v, want %v", got, want)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.observe("/", http.StatusTooManyRequests)
+	}
+	if got, want := l.Rate("/"), 1.0; got != want {
+		t.Errorf("Rate after many 429 = %v, want %v", got, want)
+	}
+
+	l.observe("/", http.StatusOK)
+	if got, want := l.Rate("/"), 2.0; got != want {
+		t.Errorf("Rate after 200 = %v, want %v", got, want)
+	}
+
+	for i := 0; i < 20; i++ {
+		l.observe("/", http.StatusOK)
+	}
+	if got, want := l.Rate("/"), 16.0; got != want {
+		t.Errorf("Rate after many 200 = %v, want %v", got, want)
+	}
+}
+
+func TestDo_rateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client.RateLimiter = NewRateLimiter(RateLimit{Rate: 1000})
+	client.RateLimiter.Adaptive = true
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected HTTP 429 error.")
+	}
+	if got, want := client.RateLimiter.Rate("/"), 500.0; got != want {
+		t.Errorf("Rate after 429 = %v, want %v", got, want)
+	}
+}
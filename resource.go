@@ -1,6 +1,10 @@
 package applemusic
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
 
 // Resource represents a resource—such as an album, song, or playlist—in the Apple Music catalog or iCloud Music Library.
 type Resource struct {
@@ -21,27 +25,87 @@ func (r Resource) Type() string {
 
 // Parse parses the Resource.
 // For recognized Resource types, a value of the corresponding struct type will be returned.
+// Types registered with RegisterResourceType are recognized as well.
 func (r Resource) Parse() (resource interface{}, err error) {
-	switch r.Type() {
-	case "albums":
-		resource = &Album{}
-	case "library-music-videos":
-		resource = &LibraryMusicVideo{}
-	case "library-songs":
-		resource = &LibrarySong{}
-	case "music-videos":
-		resource = &MusicVideo{}
-	case "playlists":
-		resource = &Playlist{}
-	case "songs":
-		resource = &Song{}
-	case "stations":
-		resource = &Station{}
+	if newResource := lookupResourceType(r.Type()); newResource != nil {
+		resource = newResource()
 	}
 	err = json.Unmarshal(r.RawMessage, &resource)
 	return resource, err
 }
 
+var (
+	resourceTypesMu sync.RWMutex
+	resourceTypes   = map[string]func() interface{}{
+		"activities":              func() interface{} { return &Activity{} },
+		"albums":                  func() interface{} { return &Album{} },
+		"apple-curators":          func() interface{} { return &Curator{} },
+		"artists":                 func() interface{} { return &Artist{} },
+		"curators":                func() interface{} { return &Curator{} },
+		"genres":                  func() interface{} { return &Genre{} },
+		"library-albums":          func() interface{} { return &LibraryAlbum{} },
+		"library-artists":         func() interface{} { return &LibraryArtist{} },
+		"library-music-videos":    func() interface{} { return &LibraryMusicVideo{} },
+		"library-playlists":       func() interface{} { return &LibraryPlaylist{} },
+		"library-songs":           func() interface{} { return &LibrarySong{} },
+		"music-videos":            func() interface{} { return &MusicVideo{} },
+		"personal-recommendation": func() interface{} { return &Recommendation{} },
+		"playlists":               func() interface{} { return &Playlist{} },
+		"ratings":                 func() interface{} { return &Rating{} },
+		"songs":                   func() interface{} { return &Song{} },
+		"stations":                func() interface{} { return &Station{} },
+		"storefronts":             func() interface{} { return &Storefront{} },
+	}
+)
+
+// RegisterResourceType registers the resource type typ, so that Resource.Parse unmarshals
+// the resources of that type into the value returned by newResource, which must be a pointer.
+// Registering a type that is already known replaces its struct type.
+// It is safe to call RegisterResourceType concurrently with Resource.Parse.
+func RegisterResourceType(typ string, newResource func() interface{}) {
+	resourceTypesMu.Lock()
+	defer resourceTypesMu.Unlock()
+
+	resourceTypes[typ] = newResource
+}
+
+func lookupResourceType(typ string) func() interface{} {
+	resourceTypesMu.RLock()
+	defer resourceTypesMu.RUnlock()
+
+	return resourceTypes[typ]
+}
+
+// parseAll parses each of the resources with Resource.Parse.
+func parseAll(resources []Resource) ([]interface{}, error) {
+	parsed := make([]interface{}, 0, len(resources))
+	for _, r := range resources {
+		resource, err := r.Parse()
+		if err != nil {
+			return parsed, err
+		}
+		parsed = append(parsed, resource)
+	}
+	return parsed, nil
+}
+
+// parseResources unmarshals the resources of type typ into dst, a pointer to a slice of the corresponding struct type.
+// Resources of other types are skipped.
+func parseResources(resources []Resource, typ string, dst interface{}) error {
+	slice := reflect.ValueOf(dst).Elem()
+	for _, r := range resources {
+		if r.Type() != typ {
+			continue
+		}
+		v := reflect.New(slice.Type().Elem())
+		if err := json.Unmarshal(r.RawMessage, v.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, v.Elem()))
+	}
+	return nil
+}
+
 // Artwork represents an artwork.
 type Artwork struct {
 	Width      int    `json:"width"`
@@ -55,6 +119,27 @@ type Artwork struct {
 	IsMosaic   bool   `json:"isMosaic,omitempty"` // Undocumented, Used in Playlists.
 }
 
+// EditorialArtwork represents the editorial artworks of a resource, keyed by their usage such as bannerUber.
+type EditorialArtwork map[string]Artwork
+
+// MotionVideo represents an editorial motion video.
+type MotionVideo struct {
+	PreviewFrame Artwork `json:"previewFrame"`
+	Video        string  `json:"video"`
+}
+
+// EditorialVideo represents the editorial motion videos of a resource, keyed by their usage such as motionDetailSquare.
+type EditorialVideo map[string]MotionVideo
+
+// ExtendedAssetURLs represents the URLs of the extended assets of a song.
+type ExtendedAssetURLs struct {
+	EnhancedHls      string `json:"enhancedHls,omitempty"`
+	Lightweight      string `json:"lightweight,omitempty"`
+	LightweightPlus  string `json:"lightweightPlus,omitempty"`
+	Plus             string `json:"plus,omitempty"`
+	SuperLightweight string `json:"superLightweight,omitempty"`
+}
+
 // EditorialNotes represents notes.
 type EditorialNotes struct {
 	Standard string `json:"standard"`
@@ -30,3 +30,123 @@ func TestResource_Parse(t *testing.T) {
 		t.Errorf("Resource.Parse returned %+v, want %+v", got, want)
 	}
 }
+
+func TestResource_Parse_types(t *testing.T) {
+	testCases := []struct {
+		typ  string
+		want interface{}
+	}{
+		{"activities", &Activity{Type: "activities"}},
+		{"albums", &Album{Type: "albums"}},
+		{"apple-curators", &Curator{Type: "apple-curators"}},
+		{"artists", &Artist{Type: "artists"}},
+		{"curators", &Curator{Type: "curators"}},
+		{"genres", &Genre{Type: "genres"}},
+		{"library-albums", &LibraryAlbum{Type: "library-albums"}},
+		{"library-artists", &LibraryArtist{Type: "library-artists"}},
+		{"library-music-videos", &LibraryMusicVideo{Type: "library-music-videos"}},
+		{"library-playlists", &LibraryPlaylist{Type: "library-playlists"}},
+		{"library-songs", &LibrarySong{Type: "library-songs"}},
+		{"music-videos", &MusicVideo{Type: "music-videos"}},
+		{"personal-recommendation", &Recommendation{Type: "personal-recommendation"}},
+		{"playlists", &Playlist{Type: "playlists"}},
+		{"ratings", &Rating{Type: "ratings"}},
+		{"songs", &Song{Type: "songs"}},
+		{"stations", &Station{Type: "stations"}},
+		{"storefronts", &Storefront{Type: "storefronts"}},
+		{"unknown", map[string]interface{}{"type": "unknown"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.typ, func(t *testing.T) {
+			resource := Resource{[]byte(`{"type": "` + tc.typ + `"}`)}
+			got, err := resource.Parse()
+			if err != nil {
+				t.Fatalf("Resource.Parse returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Resource.Parse returned %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterResourceType(t *testing.T) {
+	type custom struct {
+		Id   string `json:"id"`
+		Type string `json:"type"`
+	}
+	RegisterResourceType("custom", func() interface{} { return &custom{} })
+
+	resource := Resource{[]byte(`{"id": "1", "type": "custom"}`)}
+	got, err := resource.Parse()
+	if err != nil {
+		t.Fatalf("Resource.Parse returned unexpected error: %v", err)
+	}
+	if want := (&custom{Id: "1", Type: "custom"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Resource.Parse returned %+v, want %+v", got, want)
+	}
+}
+
+func TestTracks_accessors(t *testing.T) {
+	tracks := Tracks{
+		Data: []Resource{
+			{[]byte(`{"id": "1", "type": "songs"}`)},
+			{[]byte(`{"id": "2", "type": "music-videos"}`)},
+			{[]byte(`{"id": "3", "type": "songs"}`)},
+			{[]byte(`{"id": "i.4", "type": "library-songs"}`)},
+		},
+	}
+
+	songs, err := tracks.Songs()
+	if err != nil {
+		t.Fatalf("Tracks.Songs returned unexpected error: %v", err)
+	}
+	if want := []Song{{Id: "1", Type: "songs"}, {Id: "3", Type: "songs"}}; !reflect.DeepEqual(songs, want) {
+		t.Errorf("Tracks.Songs returned %+v, want %+v", songs, want)
+	}
+
+	musicVideos, err := tracks.MusicVideos()
+	if err != nil {
+		t.Fatalf("Tracks.MusicVideos returned unexpected error: %v", err)
+	}
+	if want := []MusicVideo{{Id: "2", Type: "music-videos"}}; !reflect.DeepEqual(musicVideos, want) {
+		t.Errorf("Tracks.MusicVideos returned %+v, want %+v", musicVideos, want)
+	}
+
+	librarySongs, err := tracks.LibrarySongs()
+	if err != nil {
+		t.Fatalf("Tracks.LibrarySongs returned unexpected error: %v", err)
+	}
+	if want := []LibrarySong{{Id: "i.4", Type: "library-songs"}}; !reflect.DeepEqual(librarySongs, want) {
+		t.Errorf("Tracks.LibrarySongs returned %+v, want %+v", librarySongs, want)
+	}
+
+	libraryMusicVideos, err := tracks.LibraryMusicVideos()
+	if err != nil {
+		t.Fatalf("Tracks.LibraryMusicVideos returned unexpected error: %v", err)
+	}
+	if libraryMusicVideos != nil {
+		t.Errorf("Tracks.LibraryMusicVideos returned %+v, want nil", libraryMusicVideos)
+	}
+}
+
+func TestHistoryHeavyRotation_Albums(t *testing.T) {
+	albums, err := meHistoryHeavyRotation.Albums()
+	if err != nil {
+		t.Fatalf("HistoryHeavyRotation.Albums returned unexpected error: %v", err)
+	}
+	if len(albums) != 1 {
+		t.Fatalf("HistoryHeavyRotation.Albums returned %d albums, want 1", len(albums))
+	}
+	if got, want := albums[0].Attributes.Name, "The Essential Celine Dion"; got != want {
+		t.Errorf("HistoryHeavyRotation.Albums name is %v, want %v", got, want)
+	}
+
+	playlists, err := meHistoryHeavyRotation.Playlists()
+	if err != nil {
+		t.Fatalf("HistoryHeavyRotation.Playlists returned unexpected error: %v", err)
+	}
+	if len(playlists) != 0 {
+		t.Errorf("HistoryHeavyRotation.Playlists returned %+v, want none", playlists)
+	}
+}
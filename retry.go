@@ -0,0 +1,176 @@
+package applemusic
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = 1 * time.Second
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy specifies how the Client retries requests that failed with
+// a Too Many Requests (429), Internal Server Error (500), Bad Gateway (502),
+// Service Unavailable (503) or Gateway Timeout (504) error.
+//
+// Only idempotent requests are replayed, such as GET, PUT and DELETE requests,
+// unless RetryNonIdempotent is set. Requests with a body are replayed only if their body
+// can be re-buffered through http.Request.GetBody, as with the requests created by Client.NewRequest.
+type RetryPolicy struct {
+	// The maximum number of attempts, including the first one.
+	// If zero, 3 attempts are made.
+	MaxAttempts int
+
+	// The backoff before the first retry, doubled after each attempt.
+	// If zero, 1 second is used.
+	MinBackoff time.Duration
+
+	// The upper limit of the backoff between attempts, including the delay
+	// requested by the Retry-After header of the response, which is capped to it.
+	// If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent, when true, also replays the POST and PATCH requests, such as the creation of
+	// a library playlist, which may then be applied twice if the failed attempt reached the server.
+	RetryNonIdempotent bool
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return defaultRetryMaxAttempts
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return defaultRetryMaxBackoff
+}
+
+// backoff returns the exponential backoff before the given retry attempt, with jitter.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.maxBackoff()
+	if minBackoff <= 0 {
+		minBackoff = defaultRetryMinBackoff
+	}
+
+	d := minBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+
+	// Full jitter on the upper half of the backoff.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryable reports whether the request can be sent again after the response.
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return false
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+	default:
+		if !p.RetryNonIdempotent {
+			return false
+		}
+	}
+	return req.Body == nil || req.GetBody != nil
+}
+
+// retryAfter parses the Retry-After header, either in delay-seconds or HTTP-date format.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// send sends the request, waiting for the RateLimiter and retrying according to the RetryPolicy of the client.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	policy, limiter := c.RetryPolicy, c.RateLimiter
+	if isForeignHost(req) {
+		// The rate limits and failures of other hosts are not those of the API.
+		policy, limiter = nil, nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx, req.URL.Path); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if limiter != nil {
+			limiter.observe(req.URL.Path, resp.StatusCode)
+		}
+
+		if policy == nil || attempt >= policy.maxAttempts() || !policy.retryable(req, resp) {
+			return resp, nil
+		}
+
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = policy.backoff(attempt)
+		} else if delay > policy.maxBackoff() {
+			delay = policy.maxBackoff()
+		}
+
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.WithContext(ctx)
+			req.Body = body
+		}
+	}
+}
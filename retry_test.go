@@ -0,0 +1,240 @@
+package applemusic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// scriptedHandler responds with the given status codes in order, then with 200 OK.
+func scriptedHandler(t *testing.T, statuses []int, calls *int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if body != "" {
+			testJsonBodyValues(t, r, []byte(body))
+		}
+		*calls++
+		if *calls <= len(statuses) {
+			w.WriteHeader(statuses[*calls-1])
+			fmt.Fprint(w, `{"message":"API capacity exceeded"}`)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	}
+}
+
+func TestDo_retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{429, 503}, &calls, ""))
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	body := new(struct{ A string })
+	resp, err := client.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Response status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if body.A != "a" {
+		t.Errorf("Response body = %v, want a", body.A)
+	}
+	if calls != 3 {
+		t.Errorf("Server called %d times, want 3", calls)
+	}
+}
+
+func TestDo_retryPOSTBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{503}, &calls, `{"name":"foo"}`))
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond, RetryNonIdempotent: true}
+
+	req, _ := client.NewRequest("POST", "/", map[string]string{"name": "foo"})
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Server called %d times, want 2", calls)
+	}
+}
+
+func TestDo_retryMaxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{429, 429, 429}, &calls, ""))
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if _, ok := err.(*TooManyRequestsError); !ok {
+		t.Errorf("Do returned error %v, want *TooManyRequestsError", err)
+	}
+	if calls != 2 {
+		t.Errorf("Server called %d times, want 2", calls)
+	}
+}
+
+func TestDo_retryDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{503}, &calls, ""))
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected HTTP 503 error.")
+	}
+	if calls != 1 {
+		t.Errorf("Server called %d times, want 1", calls)
+	}
+}
+
+func TestDo_retryNotOnClientError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{400}, &calls, ""))
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond}
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected HTTP 400 error.")
+	}
+	if calls != 1 {
+		t.Errorf("Server called %d times, want 1", calls)
+	}
+}
+
+func TestDo_retryNotOnPOST(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{503}, &calls, `{"name":"foo"}`))
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond}
+
+	req, _ := client.NewRequest("POST", "/", map[string]string{"name": "foo"})
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected HTTP 503 error.")
+	}
+	if calls != 1 {
+		t.Errorf("Server called %d times, want 1", calls)
+	}
+}
+
+func TestDo_retryNotOnNotImplemented(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", scriptedHandler(t, []int{501}, &calls, ""))
+	client.RetryPolicy = &RetryPolicy{MinBackoff: time.Millisecond}
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected HTTP 501 error.")
+	}
+	if calls != 1 {
+		t.Errorf("Server called %d times, want 1", calls)
+	}
+}
+
+func TestDo_retryCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client.RetryPolicy = &RetryPolicy{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != context.DeadlineExceeded {
+		t.Errorf("Do returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDo_retryAfterCapped(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+	client.RetryPolicy = &RetryPolicy{MaxBackoff: 10 * time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Server called %d times, want 2", calls)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	testCases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, tc := range testCases {
+		if got := p.backoff(tc.attempt); got < tc.min || got > tc.max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", tc.attempt, got, tc.min, tc.max)
+		}
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"2", 2 * time.Second, true},
+		{"-1", 0, false},
+		{"invalid", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+	}
+	for _, tc := range testCases {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", tc.header)
+		got, ok := retryAfter(resp)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tc.header, got, ok, tc.want, tc.ok)
+		}
+	}
+}
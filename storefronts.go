@@ -58,12 +58,15 @@ func (s *StorefrontsService) Get(ctx context.Context, id string, opt *Options) (
 // GetByIds fetches multiple storefronts by ids.
 func (s *StorefrontsService) GetByIds(ctx context.Context, ids []string, opt *Options) (*Storefronts, *Response, error) {
 	u := "v1/storefronts"
-	u, err := addOptions(u, makeIdsOptions(ids, opt))
+	storefronts := &Storefronts{}
+	resp, err := s.client.getInBatches(ctx, ids, maxIdsPerRequest, storefronts, func(batch []string) (string, error) {
+		return addOptions(u, makeIdsOptions(batch, opt))
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, resp, err
 	}
 
-	return s.get(ctx, u)
+	return storefronts, resp, nil
 }
 
 // GetAll fetches all the storefronts in alphabetical order.
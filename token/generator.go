@@ -28,8 +28,11 @@ type Generator struct {
 
 // Generate generates a JWT token.
 func (g Generator) Generate() (string, error) {
-	now := time.Now()
+	return g.generate(time.Now())
+}
 
+// generate generates a JWT token issued at now.
+func (g Generator) generate(now time.Time) (string, error) {
 	t := jwt.Token{
 		Method: jwt.SigningMethodES256,
 		Header: map[string]interface{}{
@@ -39,7 +42,7 @@ func (g Generator) Generate() (string, error) {
 		Claims: jwt.MapClaims{
 			"iss": g.TeamId,
 			"iat": now.Unix(),
-			"exp": now.Add(time.Second * time.Duration(g.TTL)).Unix(),
+			"exp": now.Add(g.ttl()).Unix(),
 		},
 		Signature: string(g.Secret),
 	}
@@ -52,6 +55,10 @@ func (g Generator) Generate() (string, error) {
 	return t.SignedString(key)
 }
 
+func (g Generator) ttl() time.Duration {
+	return time.Second * time.Duration(g.TTL)
+}
+
 // ParsePKCS8PrivateKeyFromPEM parses PEM encoded PKCS8 Private Key Structure.
 func ParsePKCS8PrivateKeyFromPEM(key []byte) (*ecdsa.PrivateKey, error) {
 	var err error
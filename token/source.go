@@ -0,0 +1,76 @@
+package token
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRefreshMargin is the default margin before expiration at which a Source re-signs its token.
+const DefaultRefreshMargin = 5 * time.Minute
+
+// Provider is the interface that supplies Apple Music developer tokens.
+type Provider interface {
+	// Token returns a developer token that is valid at the time of the call.
+	Token() (string, error)
+}
+
+// Source is a Provider that caches the token generated by its Generator and
+// re-signs it before it expires.
+// It is safe for concurrent use by multiple goroutines.
+type Source struct {
+	gen    Generator
+	margin time.Duration
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+
+	now func() time.Time // Used by tests to control the clock.
+}
+
+// NewSource returns a Source that generates tokens with g and re-signs them
+// when they expire within margin. If margin is zero, DefaultRefreshMargin is used.
+// A margin that is not shorter than the TTL of g is reduced to half the TTL.
+func NewSource(g Generator, margin time.Duration) *Source {
+	if margin <= 0 {
+		margin = DefaultRefreshMargin
+	}
+	if ttl := g.ttl(); margin >= ttl {
+		margin = ttl / 2
+	}
+
+	return &Source{
+		gen:    g,
+		margin: margin,
+		now:    time.Now,
+	}
+}
+
+// Token returns the cached token, generating a new one if there is none yet
+// or if the cached token expires within the refresh margin.
+func (s *Source) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Add(s.margin).Before(s.expiry) {
+		return s.token, nil
+	}
+
+	t, err := s.gen.generate(now)
+	if err != nil {
+		return "", err
+	}
+	s.token = t
+	s.expiry = now.Add(s.gen.ttl())
+
+	return s.token, nil
+}
+
+// Expiry returns the expiration time of the cached token, or the zero time if no token has been generated yet.
+func (s *Source) Expiry() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expiry
+}
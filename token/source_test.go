@@ -0,0 +1,113 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testGenerator(t *testing.T, ttl int64) Generator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey returned error: %v", err)
+	}
+
+	return Generator{
+		KeyId:  "KEY_ID",
+		TeamId: "TEAM_ID",
+		TTL:    ttl,
+		Secret: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+	}
+}
+
+func TestSource_Token(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	s := NewSource(testGenerator(t, 3600), 10*time.Minute)
+	s.now = func() time.Time { return now }
+
+	first, err := s.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got, want := s.Expiry(), now.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Expiry = %v, want %v", got, want)
+	}
+
+	// Still outside of the refresh margin, the cached token is returned.
+	now = now.Add(49 * time.Minute)
+	cached, err := s.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if cached != first {
+		t.Errorf("Token = %v, want cached token %v", cached, first)
+	}
+
+	// Within the refresh margin, the token is re-signed.
+	now = now.Add(2 * time.Minute)
+	refreshed, err := s.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if refreshed == first {
+		t.Error("Token returned the cached token, want a re-signed token")
+	}
+	if got, want := s.Expiry(), now.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Expiry = %v, want %v", got, want)
+	}
+}
+
+func TestSource_Token_invalidKey(t *testing.T) {
+	s := NewSource(Generator{TTL: 3600, Secret: []byte("invalid")}, 0)
+	if _, err := s.Token(); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestSource_Token_concurrent(t *testing.T) {
+	s := NewSource(testGenerator(t, 3600), 0)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 10)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = s.Token()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, tok := range tokens {
+		if tok == "" || tok != tokens[0] {
+			t.Fatalf("Token returned %q, want the same token for every goroutine", tok)
+		}
+	}
+}
+
+func TestNewSource_margin(t *testing.T) {
+	testCases := []struct {
+		ttl    int64
+		margin time.Duration
+		want   time.Duration
+	}{
+		{3600, 0, DefaultRefreshMargin},
+		{3600, time.Minute, time.Minute},
+		{60, 0, 30 * time.Second},
+	}
+	for _, tc := range testCases {
+		s := NewSource(Generator{TTL: tc.ttl}, tc.margin)
+		if s.margin != tc.want {
+			t.Errorf("NewSource(TTL %d, %v) margin = %v, want %v", tc.ttl, tc.margin, s.margin, tc.want)
+		}
+	}
+}